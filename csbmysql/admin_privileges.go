@@ -0,0 +1,208 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// databasePrivileges are the privileges of GRANT ALL on a database, all of which the
+// admin user must have itself, with GRANT OPTION, to grant them to a binding user
+var databasePrivileges = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES", "INDEX", "ALTER",
+	"CREATE TEMPORARY TABLES", "LOCK TABLES", "EXECUTE", "CREATE VIEW", "SHOW VIEW",
+	"CREATE ROUTINE", "ALTER ROUTINE", "EVENT", "TRIGGER",
+}
+
+// requiredPrivilege is a privilege the admin user needs on a database, or globally when database is empty.
+// For PROXY, proxied is the user@host account it is needed on. alternatives are privileges that also allow
+// the operation, such as SUPER on servers without dynamic privileges.
+type requiredPrivilege struct {
+	privilege    string
+	database     string
+	proxied      string
+	grantOption  bool
+	alternatives []requiredPrivilege
+}
+
+func (r requiredPrivilege) String() string {
+	scope := "*.*"
+	switch {
+	case r.proxied != "":
+		scope = r.proxied
+	case r.database != "":
+		scope = quotedIdentifier(r.database) + ".*"
+	}
+	result := fmt.Sprintf("%s ON %s", r.privilege, scope)
+	if r.grantOption {
+		result += " WITH GRANT OPTION"
+	}
+	for _, alternative := range r.alternatives {
+		result += " or " + alternative.String()
+	}
+	return result
+}
+
+var (
+	// dropUserPrivilege allows DROP USER, which the DELETE privilege on the mysql schema also allows
+	dropUserPrivilege = requiredPrivilege{privilege: "CREATE USER", alternatives: []requiredPrivilege{{privilege: "DELETE", database: "mysql"}}}
+	// auditAdminPrivilege allows calling the audit_log_filter_* functions
+	auditAdminPrivilege = requiredPrivilege{privilege: "AUDIT_ADMIN", alternatives: []requiredPrivilege{{privilege: "SUPER"}}}
+)
+
+// adminGrantsCache holds the grants of the admin user, which are read once as they
+// are not expected to change while the provider runs
+type adminGrantsCache struct {
+	once   sync.Once
+	grants []grant
+	err    error
+}
+
+func (c connectionFactory) adminGrants(ctx context.Context, db *sql.DB) ([]grant, error) {
+	read := func() ([]grant, error) {
+		statements, err := queryStrings(ctx, db, "SHOW GRANTS FOR CURRENT_USER()")
+		if err != nil {
+			return nil, fmt.Errorf("error reading the grants of the admin user: %w", err)
+		}
+		return parseGrants(statements)
+	}
+
+	if c.grantsCache == nil {
+		return read()
+	}
+	c.grantsCache.once.Do(func() {
+		c.grantsCache.grants, c.grantsCache.err = read()
+	})
+	return c.grantsCache.grants, c.grantsCache.err
+}
+
+// checkAdminPrivileges fails listing the required privileges the admin user does not have, so that
+// a resource fails before any change is made. The check is skipped when the grants cannot be read,
+// and when the admin user has roles, as SHOW GRANTS does not show what they grant. Cloud admin
+// users such as rds_superuser_role and cloudsqlsuperuser get their privileges from a role.
+func checkAdminPrivileges(ctx context.Context, cf connectionFactory, db *sql.DB, required []requiredPrivilege) error {
+	grants, err := cf.adminGrants(ctx, db)
+	if err != nil {
+		log.Printf("[DEBUG] skipping admin privileges check: %s", err)
+		return nil
+	}
+	for _, g := range grants {
+		if g.kind == grantKindRole {
+			log.Println("[DEBUG] skipping admin privileges check as the admin user has roles")
+			return nil
+		}
+	}
+
+	missing := missingPrivileges(grants, required)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(missing))
+	for _, m := range missing {
+		descriptions = append(descriptions, m.String())
+	}
+	return fmt.Errorf("the admin user %q is missing privileges: %s", cf.username, strings.Join(descriptions, ", "))
+}
+
+// checkAdminPrivilegesAtPlan runs checkAdminPrivileges from a CustomizeDiff, so that the plan fails rather than the apply.
+// It is skipped when the server cannot be reached, for example because it is created in the same apply.
+func checkAdminPrivilegesAtPlan(ctx context.Context, cf connectionFactory, action string, required []requiredPrivilege) error {
+	if len(required) == 0 {
+		return nil
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		log.Printf("[DEBUG] skipping admin privileges check: %s", err)
+		return nil
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := checkAdminPrivileges(ctx, cf, db, required); err != nil {
+		return fmt.Errorf("unable to %s: %w", action, err)
+	}
+	return nil
+}
+
+// missingPrivileges returns the required privileges that none of the grants give
+func missingPrivileges(grants []grant, required []requiredPrivilege) []requiredPrivilege {
+	var missing []requiredPrivilege
+	for _, r := range required {
+		if !hasPrivilege(grants, r) {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+func hasPrivilege(grants []grant, required requiredPrivilege) bool {
+	for _, alternative := range required.alternatives {
+		if hasPrivilege(grants, alternative) {
+			return true
+		}
+	}
+	if required.proxied != "" {
+		return hasProxyPrivilege(grants, required)
+	}
+
+	for _, g := range grants {
+		if g.kind != grantKindPrivilege || g.table != "*" || g.column != "" {
+			continue
+		}
+		if required.grantOption && !g.grantOption {
+			continue
+		}
+		if g.database != "*" && (required.database == "" || !databasePatternMatches(g.database, required.database)) {
+			continue
+		}
+		for _, privilege := range g.privileges {
+			if privilege == required.privilege || privilege == "ALL" || privilege == "ALL PRIVILEGES" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasProxyPrivilege also accepts PROXY on the account with an empty user and host, which
+// the root user has so that it can grant PROXY on any account
+func hasProxyPrivilege(grants []grant, required requiredPrivilege) bool {
+	for _, g := range grants {
+		if g.kind != grantKindProxy || (required.grantOption && !g.grantOption) {
+			continue
+		}
+		if g.proxiedUser == required.proxied || g.proxiedUser == "@" {
+			return true
+		}
+	}
+	return false
+}
+
+// databasePatternMatches matches the database of a grant, in which % and _ are wildcards
+// unless escaped with a backslash, against the name of a database
+func databasePatternMatches(pattern, database string) bool {
+	var expression strings.Builder
+	expression.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expression.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case c == '%':
+			expression.WriteString(".*")
+		case c == '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String()).MatchString(database)
+}
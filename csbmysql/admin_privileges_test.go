@@ -0,0 +1,93 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Admin privileges", func() {
+	mustParseGrants := func(statements ...string) []grant {
+		grants, err := parseGrants(statements)
+		Expect(err).NotTo(HaveOccurred())
+		return grants
+	}
+
+	required := []requiredPrivilege{
+		{privilege: "CREATE USER"},
+		{privilege: "SELECT", database: "app_db", grantOption: true},
+		{privilege: "RESOURCE_GROUP_USER", grantOption: true},
+	}
+
+	It("finds nothing missing for an admin with ALL PRIVILEGES", func() {
+		grants := mustParseGrants("GRANT ALL PRIVILEGES ON *.* TO `root`@`%` WITH GRANT OPTION")
+		Expect(missingPrivileges(grants, required)).To(BeEmpty())
+	})
+
+	It("finds nothing missing for an admin with the privileges listed", func() {
+		grants := mustParseGrants(
+			"GRANT SELECT, INSERT, CREATE USER ON *.* TO `admin`@`%` WITH GRANT OPTION",
+			"GRANT RESOURCE_GROUP_USER ON *.* TO `admin`@`%` WITH GRANT OPTION",
+		)
+		Expect(missingPrivileges(grants, required)).To(BeEmpty())
+	})
+
+	It("accepts database privileges granted on a database pattern", func() {
+		grants := mustParseGrants("GRANT ALL PRIVILEGES ON `app\\_%`.* TO `admin`@`%` WITH GRANT OPTION")
+		Expect(missingPrivileges(grants, []requiredPrivilege{{privilege: "SELECT", database: "app_db", grantOption: true}})).To(BeEmpty())
+		Expect(missingPrivileges(grants, []requiredPrivilege{{privilege: "SELECT", database: "appdb", grantOption: true}})).To(HaveLen(1))
+	})
+
+	It("returns the privileges that are missing", func() {
+		grants := mustParseGrants(
+			"GRANT USAGE ON *.* TO `admin`@`%`",
+			"GRANT SELECT ON `app_db`.* TO `admin`@`%`",
+			"GRANT RESOURCE_GROUP_USER ON *.* TO `admin`@`%` WITH GRANT OPTION",
+			"GRANT SELECT ON `app_db`.`t` TO `admin`@`%` WITH GRANT OPTION",
+		)
+		missing := missingPrivileges(grants, required)
+		Expect(missing).To(HaveLen(2))
+		Expect(missing[0].String()).To(Equal("CREATE USER ON *.*"))
+		Expect(missing[1].String()).To(Equal("SELECT ON `app_db`.* WITH GRANT OPTION"))
+	})
+
+	It("accepts an alternative privilege", func() {
+		grants := mustParseGrants("GRANT SUPER ON *.* TO `admin`@`%`")
+		required := []requiredPrivilege{{privilege: "SYSTEM_VARIABLES_ADMIN", alternatives: []requiredPrivilege{{privilege: "SUPER"}}}}
+		Expect(missingPrivileges(grants, required)).To(BeEmpty())
+
+		missing := missingPrivileges(mustParseGrants("GRANT USAGE ON *.* TO `admin`@`%`"), required)
+		Expect(missing).To(HaveLen(1))
+		Expect(missing[0].String()).To(Equal("SYSTEM_VARIABLES_ADMIN ON *.* or SUPER ON *.*"))
+	})
+
+	It("accepts DELETE on the mysql schema for DROP USER", func() {
+		grants := mustParseGrants("GRANT DELETE ON `mysql`.* TO `admin`@`%`")
+		Expect(missingPrivileges(grants, []requiredPrivilege{dropUserPrivilege})).To(BeEmpty())
+
+		missing := missingPrivileges(mustParseGrants("GRANT DELETE ON `app_db`.* TO `admin`@`%`"), []requiredPrivilege{dropUserPrivilege})
+		Expect(missing).To(HaveLen(1))
+		Expect(missing[0].String()).To(Equal("CREATE USER ON *.* or DELETE ON `mysql`.*"))
+	})
+
+	DescribeTable("PROXY on an account",
+		func(statement string, expected bool) {
+			required := requiredPrivilege{privilege: "PROXY", proxied: "app@%", grantOption: true}
+			Expect(hasPrivilege(mustParseGrants(statement), required)).To(Equal(expected))
+		},
+		Entry("on any account", "GRANT PROXY ON ``@`` TO `root`@`localhost` WITH GRANT OPTION", true),
+		Entry("on the account", "GRANT PROXY ON `app`@`%` TO `admin`@`%` WITH GRANT OPTION", true),
+		Entry("without grant option", "GRANT PROXY ON `app`@`%` TO `admin`@`%`", false),
+		Entry("on another account", "GRANT PROXY ON `other`@`%` TO `admin`@`%` WITH GRANT OPTION", false),
+	)
+
+	DescribeTable("databasePatternMatches",
+		func(pattern, database string, matches bool) {
+			Expect(databasePatternMatches(pattern, database)).To(Equal(matches))
+		},
+		Entry("exact name", "app_db", "app_db", true),
+		Entry("unescaped underscore is a wildcard", "app_db", "app-db", true),
+		Entry("escaped underscore is literal", `app\_db`, "app-db", false),
+		Entry("percent", "app%", "application", true),
+		Entry("different name", "app", "application", false),
+	)
+})
@@ -0,0 +1,60 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errAuditLogFilterUnavailable is returned when neither the MySQL Enterprise audit_log
+// plugin nor the Percona Server audit_log_filter plugin or component is installed
+var errAuditLogFilterUnavailable = errors.New("audit log filtering unavailable: install the audit_log plugin (MySQL Enterprise) or audit_log_filter (Percona Server)")
+
+// requireAuditLogFilter checks that the audit_log_filter_* functions are available
+func requireAuditLogFilter(ctx context.Context, db *sql.DB, d dialect) error {
+	var plugins int
+	if err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM information_schema.PLUGINS WHERE PLUGIN_NAME IN ('audit_log', 'audit_log_filter') AND PLUGIN_STATUS = 'ACTIVE'",
+	).Scan(&plugins); err != nil {
+		return fmt.Errorf("error checking for the audit log plugin: %w", err)
+	}
+	if plugins > 0 {
+		return nil
+	}
+
+	if d.supports(featureComponents) {
+		var components int
+		if err := db.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM mysql.component WHERE component_urn = 'file://component_audit_log_filter'",
+		).Scan(&components); err != nil {
+			return fmt.Errorf("error checking for the audit log component: %w", err)
+		}
+		if components > 0 {
+			return nil
+		}
+	}
+
+	return errAuditLogFilterUnavailable
+}
+
+// callAuditLogFunction runs one of the audit_log_filter_* functions, which report
+// failure with a result starting with ERROR rather than with a MySQL error
+func callAuditLogFunction(ctx context.Context, db *sql.DB, function string, args ...any) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+
+	var result string
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("SELECT %s(%s)", function, placeholders), args...).Scan(&result); err != nil {
+		return fmt.Errorf("error calling %s: %w", function, err)
+	}
+	if !strings.HasPrefix(result, "OK") {
+		return fmt.Errorf("error calling %s: %s", function, result)
+	}
+	return nil
+}
+
+// auditLogAccount is the user@host form the audit_log_filter_* functions take
+func auditLogAccount(username, host string) string {
+	return fmt.Sprintf("%s@%s", username, host)
+}
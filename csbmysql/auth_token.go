@@ -0,0 +1,136 @@
+package csbmysql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// rdsAuthTokenLifetime is fixed by AWS: an RDS IAM authentication token is valid for 15 minutes.
+	rdsAuthTokenLifetime = 15 * time.Minute
+	gcpSQLLoginScope     = "https://www.googleapis.com/auth/sqlservice.login"
+)
+
+// authToken is a short-lived credential used in place of the admin password.
+type authToken struct {
+	value  string
+	expiry time.Time
+}
+
+// authTokenGenerator generates a new authToken every time it is called.
+// Implementations should not cache, caching is done by refreshingTokenSource.
+type authTokenGenerator interface {
+	GenerateToken(ctx context.Context) (authToken, error)
+}
+
+// refreshingTokenSource caches the token from an authTokenGenerator and replaces it
+// before it becomes too old to open a connection that will be used for up to minValidity.
+type refreshingTokenSource struct {
+	generator   authTokenGenerator
+	minValidity time.Duration
+	now         func() time.Time
+
+	mutex   sync.Mutex
+	current authToken
+}
+
+func newRefreshingTokenSource(generator authTokenGenerator, minValidity time.Duration) *refreshingTokenSource {
+	return &refreshingTokenSource{
+		generator:   generator,
+		minValidity: minValidity,
+		now:         time.Now,
+	}
+}
+
+func (r *refreshingTokenSource) Token(ctx context.Context) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.current.value != "" && r.current.expiry.Sub(r.now()) > r.minValidity {
+		return r.current.value, nil
+	}
+
+	token, err := r.generator.GenerateToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	r.current = token
+	return token.value, nil
+}
+
+type rdsTokenGenerator struct {
+	endpoint    string
+	region      string
+	username    string
+	credentials aws.CredentialsProvider
+	now         func() time.Time
+}
+
+func newRDSTokenGenerator(ctx context.Context, host string, port int, region, profile, username string) (*rdsTokenGenerator, error) {
+	opts := []func(*config.LoadOptions) error{config.WithRegion(region)}
+	if profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(profile))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %w", err)
+	}
+
+	return &rdsTokenGenerator{
+		endpoint:    fmt.Sprintf("%s:%d", host, port),
+		region:      region,
+		username:    username,
+		credentials: cfg.Credentials,
+		now:         time.Now,
+	}, nil
+}
+
+func (r *rdsTokenGenerator) GenerateToken(ctx context.Context) (authToken, error) {
+	issued := r.now()
+	token, err := auth.BuildAuthToken(ctx, r.endpoint, r.region, r.username, r.credentials)
+	if err != nil {
+		return authToken{}, fmt.Errorf("unable to build RDS IAM authentication token: %w", err)
+	}
+
+	return authToken{value: token, expiry: issued.Add(rdsAuthTokenLifetime)}, nil
+}
+
+type gcpTokenGenerator struct {
+	tokenSource oauth2.TokenSource
+}
+
+func newGCPTokenGenerator(ctx context.Context, credentialsJSON string) (*gcpTokenGenerator, error) {
+	var (
+		credentials *google.Credentials
+		err         error
+	)
+	if credentialsJSON != "" {
+		credentials, err = google.CredentialsFromJSON(ctx, []byte(credentialsJSON), gcpSQLLoginScope)
+	} else {
+		credentials, err = google.FindDefaultCredentials(ctx, gcpSQLLoginScope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to load GCP credentials: %w", err)
+	}
+
+	return &gcpTokenGenerator{tokenSource: credentials.TokenSource}, nil
+}
+
+func (g *gcpTokenGenerator) GenerateToken(context.Context) (authToken, error) {
+	token, err := g.tokenSource.Token()
+	if err != nil {
+		return authToken{}, fmt.Errorf("unable to obtain GCP access token: %w", err)
+	}
+
+	return authToken{value: token.AccessToken, expiry: token.Expiry}, nil
+}
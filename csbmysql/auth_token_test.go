@@ -0,0 +1,79 @@
+package csbmysql
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeTokenGenerator struct {
+	calls    int
+	lifetime time.Duration
+	now      time.Time
+}
+
+func (f *fakeTokenGenerator) GenerateToken(context.Context) (authToken, error) {
+	f.calls++
+	return authToken{value: strings.Repeat("t", f.calls), expiry: f.now.Add(f.lifetime)}, nil
+}
+
+var _ = Describe("Auth tokens", func() {
+	Describe("refreshingTokenSource", func() {
+		var (
+			now       time.Time
+			generator *fakeTokenGenerator
+			source    *refreshingTokenSource
+		)
+
+		BeforeEach(func() {
+			now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			generator = &fakeTokenGenerator{lifetime: 15 * time.Minute, now: now}
+			source = newRefreshingTokenSource(generator, connMaxLifetime)
+			source.now = func() time.Time { return now }
+		})
+
+		It("reuses a token while it outlives the connection lifetime", func() {
+			Expect(source.Token(context.Background())).To(Equal("t"))
+			now = now.Add(11 * time.Minute)
+			Expect(source.Token(context.Background())).To(Equal("t"))
+			Expect(generator.calls).To(Equal(1))
+		})
+
+		It("refreshes a token before it would expire during the connection lifetime", func() {
+			Expect(source.Token(context.Background())).To(Equal("t"))
+			now = now.Add(13 * time.Minute)
+			Expect(source.Token(context.Background())).To(Equal("tt"))
+			Expect(generator.calls).To(Equal(2))
+		})
+	})
+
+	Describe("rdsTokenGenerator", func() {
+		It("signs a token with the given credentials", func() {
+			issued := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			generator := rdsTokenGenerator{
+				endpoint:    "mydb.example.us-east-1.rds.amazonaws.com:3306",
+				region:      "us-east-1",
+				username:    "admin",
+				credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", ""),
+				now:         func() time.Time { return issued },
+			}
+
+			token, err := generator.GenerateToken(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.expiry).To(Equal(issued.Add(rdsAuthTokenLifetime)))
+
+			u, err := url.Parse("https://" + token.value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.Host).To(Equal("mydb.example.us-east-1.rds.amazonaws.com:3306"))
+			Expect(u.Query().Get("Action")).To(Equal("connect"))
+			Expect(u.Query().Get("DBUser")).To(Equal("admin"))
+			Expect(u.Query().Get("X-Amz-Credential")).To(HavePrefix("AKIDEXAMPLE/"))
+			Expect(u.Query().Get("X-Amz-Signature")).NotTo(BeEmpty())
+		})
+	})
+})
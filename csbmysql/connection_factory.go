@@ -1,6 +1,7 @@
 package csbmysql
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
@@ -11,7 +12,10 @@ import (
 	"github.com/go-sql-driver/mysql"
 )
 
-const customCaConfigName = "custom-ca"
+const (
+	customCaConfigName = "custom-ca"
+	connMaxLifetime    = time.Minute * 3
+)
 
 type connectionFactory struct {
 	host                        string
@@ -27,6 +31,16 @@ type connectionFactory struct {
 	// accepts any certificate presented by the server and any host name in that
 	// certificate.
 	skipVerify bool
+	// requireSecureTransport makes ConnectAsAdmin fail unless the session
+	// negotiated with the server is encrypted with TLS.
+	requireSecureTransport bool
+	// authTokens, when set, replaces the static admin password with a
+	// short-lived token (cloud IAM authentication) generated at connect time.
+	authTokens *refreshingTokenSource
+	// dialect is chosen from the server flavor and version, detected on first use
+	dialect dialect
+	// grantsCache holds the grants of the admin user once they are first needed
+	grantsCache *adminGrantsCache
 }
 
 func (c connectionFactory) ConnectAsAdmin() (*sql.DB, error) {
@@ -35,7 +49,58 @@ func (c connectionFactory) ConnectAsAdmin() (*sql.DB, error) {
 			return nil, err
 		}
 	}
-	return c.connect(c.uri())
+
+	var (
+		db  *sql.DB
+		err error
+	)
+	if c.authTokens != nil {
+		db, err = c.connectWithAuthToken()
+	} else {
+		db, err = c.connect(c.uri())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.requireSecureTransport {
+		if err := ensureSecureTransport(db); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// ensureSecureTransport checks the TLS cipher negotiated for the session, which is empty when it is not encrypted.
+func ensureSecureTransport(db *sql.DB) error {
+	var name, cipher string
+	if err := db.QueryRow("SHOW STATUS LIKE 'Ssl_cipher'").Scan(&name, &cipher); err != nil {
+		return fmt.Errorf("error checking the TLS status of the admin session: %w", err)
+	}
+	if cipher == "" {
+		return fmt.Errorf("the admin session is not encrypted with TLS and %s is set", requireSecureTransportKey)
+	}
+	return nil
+}
+
+// verifiesServerIdentity reports whether the server certificate chain and host name are checked before credentials are sent.
+// The admin session always uses TLS, and skip_verify disables the check both with and without a custom CA.
+func (c connectionFactory) verifiesServerIdentity() bool {
+	return !c.skipVerify
+}
+
+func (c connectionFactory) detectServer(ctx context.Context) (serverInfo, error) {
+	db, err := c.ConnectAsAdmin()
+	if err != nil {
+		return serverInfo{}, err
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	return detectServer(ctx, db)
 }
 
 func (c connectionFactory) connect(uri string) (*sql.DB, error) {
@@ -44,12 +109,48 @@ func (c connectionFactory) connect(uri string) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to connect to MySQL %q: %w", c.uriRedacted(), err)
 	}
 
-	db.SetConnMaxLifetime(time.Minute * 3)
-	db.SetMaxOpenConns(5)
-	db.SetMaxIdleConns(5)
+	configurePool(db)
+	return db, nil
+}
+
+// connectWithAuthToken opens a connection pool that authenticates every new
+// connection with an IAM token. Cloud providers require tokens to be sent with
+// the cleartext plugin, which is acceptable because the admin connection always uses TLS.
+func (c connectionFactory) connectWithAuthToken() (*sql.DB, error) {
+	cfg, err := mysql.ParseDSN(c.uri())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL %q: %w", c.uriRedacted(), err)
+	}
 
+	cfg.AllowCleartextPasswords = true
+	err = cfg.Apply(mysql.BeforeConnect(func(ctx context.Context, cfg *mysql.Config) error {
+		token, err := c.authTokens.Token(ctx)
+		if err != nil {
+			return err
+		}
+		cfg.Passwd = token
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL %q: %w", c.uriRedacted(), err)
+	}
+
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL %q: %w", c.uriRedacted(), err)
+	}
+
+	db := sql.OpenDB(connector)
+	configurePool(db)
 	return db, nil
 }
+
+func configurePool(db *sql.DB) {
+	db.SetConnMaxLifetime(connMaxLifetime)
+	db.SetMaxOpenConns(5)
+	db.SetMaxIdleConns(5)
+}
+
 func (c connectionFactory) uriWithCreds(username, password string) string {
 	uri := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=%s", username, password, c.host, c.port, c.database, c.tlsMode())
 	return uri
@@ -111,5 +212,8 @@ func (c connectionFactory) uri() string {
 }
 
 func (c connectionFactory) uriRedacted() string {
+	if c.password == "" {
+		return c.uri()
+	}
 	return strings.ReplaceAll(c.uri(), c.password, "REDACTED")
 }
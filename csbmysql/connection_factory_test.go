@@ -0,0 +1,50 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Secure transport", func() {
+	open := func(tlsMode string) *sql.DB {
+		db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/mysql?tls=%s", adminUser, adminPass, dbHost, port, tlsMode))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(db.Close)
+		return db
+	}
+
+	It("accepts a session encrypted with TLS", func() {
+		Expect(csbmysql.EnsureSecureTransport(open("skip-verify"))).To(Succeed())
+	})
+
+	It("refuses a session that is not encrypted", func() {
+		Expect(csbmysql.EnsureSecureTransport(open("false"))).To(MatchError("the admin session is not encrypted with TLS and require_secure_transport is set"))
+	})
+
+	It("runs the provider with require_secure_transport over TLS", func() {
+		const username = "secure-transport-app"
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: testGetDefinition(csbMySQLProvider+fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username = "%s"
+  password = "app-password"
+}
+`, csbmysql.ResourceNameKey, username), providerDefinitionWithRequireSecureTransport(true)),
+					Check: checkPasswordsWork(username, map[string]bool{"app-password": true}),
+				},
+			},
+		})
+	})
+})
@@ -8,7 +8,9 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strings"
 	"testing"
 	"text/template"
 	"time"
@@ -86,6 +88,19 @@ func getMySQLVersion() string {
 	return mysqlVersion
 }
 
+// versionPrefixPattern matches the version reported by the server running the MySQL image under test
+func versionPrefixPattern() *regexp.Regexp {
+	tag, _, _ := strings.Cut(getMySQLVersion(), "-")
+	return regexp.MustCompile("^" + regexp.QuoteMeta(tag) + `\.`)
+}
+
+// skipOnMySQL57 skips specs for features that were introduced in MySQL 8
+func skipOnMySQL57() {
+	if strings.HasPrefix(getMySQLVersion(), "5.7") {
+		Skip("not supported on MySQL 5.7")
+	}
+}
+
 var _ = AfterSuite(func() {
 	mustRun("docker", "rm", "-f", "mysql")
 	mustRun("docker", "volume", "rm", "mysql_config")
@@ -137,6 +152,7 @@ type definition struct {
 	SSLClientPrivateKey string
 	Port                     int
 	SkipVerify               bool
+	RequireSecureTransport   bool
 	AllowInsecureConnections bool
 	ReadOnly                 bool
 }
@@ -144,6 +160,10 @@ type definition struct {
 type setDefinitionFunc func(*definition)
 
 func testGetResourceDefinition(optFns ...setDefinitionFunc) string {
+	return testGetDefinition(csbMySQLResource, optFns...)
+}
+
+func testGetDefinition(tmpl string, optFns ...setDefinitionFunc) string {
 	caCertPath := path.Join(getCurrentDirectory(), "testfixtures", "ssl_mysql", "certs", "ca.crt")
 	rootCertificate, err := os.ReadFile(caCertPath)
 	Expect(err).NotTo(HaveOccurred())
@@ -174,7 +194,7 @@ func testGetResourceDefinition(optFns ...setDefinitionFunc) string {
 		fn(&c)
 	}
 
-	hcl, err := parse(&c, csbMySQLResource)
+	hcl, err := parse(&c, tmpl)
 	Expect(err).NotTo(HaveOccurred())
 	return hcl
 }
@@ -203,6 +223,12 @@ func resourceDefinitionWithReadOnly(readOnly bool) setDefinitionFunc {
 	}
 }
 
+func providerDefinitionWithRequireSecureTransport(required bool) setDefinitionFunc {
+	return func(config *definition) {
+		config.RequireSecureTransport = required
+	}
+}
+
 func createFixtureVolume() {
 	mustRun("docker", "volume", "create", "mysql_config")
 	for _, folder := range []string{"certs", "keys"} {
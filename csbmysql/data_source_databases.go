@@ -0,0 +1,126 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	databasesNameRegexKey   = "name_regex"
+	databasesKey            = "databases"
+	databaseNameKey         = "name"
+	databaseCharacterSetKey = "character_set"
+	databaseCollationKey    = "collation"
+	databaseTableCountKey   = "table_count"
+	databaseDataLengthKey   = "data_length"
+	databaseIndexLengthKey  = "index_length"
+)
+
+func DataSourceDatabases() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceDatabasesSchema,
+		ReadContext: dataSourceDatabasesRead,
+		Description: "Databases on the MySQL server with their character set, collation and size",
+	}
+}
+
+var dataSourceDatabasesSchema = map[string]*schema.Schema{
+	databasesNameRegexKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsValidRegExp,
+		Description:  "Only return databases whose name matches this regular expression.",
+	},
+	databasesKey: {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				databaseNameKey:         {Type: schema.TypeString, Computed: true},
+				databaseCharacterSetKey: {Type: schema.TypeString, Computed: true},
+				databaseCollationKey:    {Type: schema.TypeString, Computed: true},
+				databaseTableCountKey:   {Type: schema.TypeInt, Computed: true},
+				databaseDataLengthKey: {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Sum of the data length of all tables, in bytes.",
+				},
+				databaseIndexLengthKey: {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Sum of the index length of all tables, in bytes.",
+				},
+			},
+		},
+	},
+}
+
+func dataSourceDatabasesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY dataSourceDatabasesRead()")
+	defer log.Println("[DEBUG] EXIT dataSourceDatabasesRead()")
+
+	nameRegex := d.Get(databasesNameRegexKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	rows, err := db.QueryContext(ctx, `SELECT s.SCHEMA_NAME, s.DEFAULT_CHARACTER_SET_NAME, s.DEFAULT_COLLATION_NAME,
+       COUNT(t.TABLE_NAME), COALESCE(SUM(t.DATA_LENGTH), 0), COALESCE(SUM(t.INDEX_LENGTH), 0)
+FROM information_schema.SCHEMATA s
+LEFT JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = s.SCHEMA_NAME
+GROUP BY s.SCHEMA_NAME, s.DEFAULT_CHARACTER_SET_NAME, s.DEFAULT_COLLATION_NAME
+ORDER BY s.SCHEMA_NAME`)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing databases: %w", err))
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	databases := make([]map[string]any, 0)
+	for rows.Next() {
+		var (
+			name, characterSet, collation       string
+			tableCount, dataLength, indexLength int64
+		)
+		if err := rows.Scan(&name, &characterSet, &collation, &tableCount, &dataLength, &indexLength); err != nil {
+			return diag.FromErr(fmt.Errorf("error listing databases: %w", err))
+		}
+		databases = append(databases, map[string]any{
+			databaseNameKey:         name,
+			databaseCharacterSetKey: characterSet,
+			databaseCollationKey:    collation,
+			databaseTableCountKey:   tableCount,
+			databaseDataLengthKey:   dataLength,
+			databaseIndexLengthKey:  indexLength,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return diag.FromErr(fmt.Errorf("error listing databases: %w", err))
+	}
+
+	filtered, err := filterByRegex(databases, databaseNameKey, nameRegex)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(databasesKey, filtered); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d/databases?name_regex=%s", cf.host, cf.port, nameRegex))
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package csbmysql_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Databases and tables data sources", func() {
+	It("reports the databases and tables on the server", func() {
+		databasesName := fmt.Sprintf("data.%s.service", csbmysql.DataSourceDatabasesNameKey)
+		tablesName := fmt.Sprintf("data.%s.service", csbmysql.DataSourceTablesNameKey)
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{{
+				Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+data "%s" "service" {
+  name_regex = "^%s$"
+}
+
+data "%s" "service" {
+  name_regex = "^previous_table$"
+}
+`, csbmysql.DataSourceDatabasesNameKey, database, csbmysql.DataSourceTablesNameKey)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(databasesName, "databases.#", "1"),
+					resource.TestCheckResourceAttr(databasesName, "databases.0.name", database),
+					resource.TestCheckResourceAttrSet(databasesName, "databases.0.character_set"),
+					resource.TestCheckResourceAttrSet(databasesName, "databases.0.collation"),
+					resource.TestCheckResourceAttrSet(databasesName, "databases.0.data_length"),
+					resource.TestCheckResourceAttr(tablesName, "database", database),
+					resource.TestCheckResourceAttr(tablesName, "tables.#", "1"),
+					resource.TestCheckResourceAttr(tablesName, "tables.0.name", "previous_table"),
+					resource.TestCheckResourceAttr(tablesName, "tables.0.type", "BASE TABLE"),
+					resource.TestCheckResourceAttr(tablesName, "tables.0.engine", "InnoDB"),
+					resource.TestCheckResourceAttrSet(tablesName, "tables.0.rows"),
+				),
+			}},
+		})
+	})
+})
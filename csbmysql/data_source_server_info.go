@@ -0,0 +1,185 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	serverInfoVersionKey           = "version"
+	serverInfoVersionCommentKey    = "version_comment"
+	serverInfoFlavorKey            = "flavor"
+	serverInfoHostnameKey          = "hostname"
+	serverInfoReadOnlyKey          = "read_only"
+	serverInfoSuperReadOnlyKey     = "super_read_only"
+	serverInfoDefaultAuthPluginKey = "default_authentication_plugin"
+	serverInfoTLSVersionKey        = "tls_version"
+	serverInfoTLSCipherKey         = "tls_cipher"
+	serverInfoRolesSupportedKey    = "roles_supported"
+	serverInfoComponentsKey        = "components"
+	serverInfoPluginsKey           = "plugins"
+)
+
+func DataSourceServerInfo() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceServerInfoSchema,
+		ReadContext: dataSourceServerInfoRead,
+		Description: "Version, flavor and capabilities of the MySQL server the provider is connected to",
+	}
+}
+
+var dataSourceServerInfoSchema = map[string]*schema.Schema{
+	serverInfoVersionKey: {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Version number of the server. For TiDB this is the TiDB release.",
+	},
+	serverInfoVersionCommentKey: {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	serverInfoFlavorKey: {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "One of \"MySQL\", \"Aurora MySQL\", \"MariaDB\" or \"TiDB\".",
+	},
+	serverInfoHostnameKey: {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	serverInfoReadOnlyKey: {
+		Type:     schema.TypeBool,
+		Computed: true,
+	},
+	serverInfoSuperReadOnlyKey: {
+		Type:     schema.TypeBool,
+		Computed: true,
+	},
+	serverInfoDefaultAuthPluginKey: {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+	serverInfoTLSVersionKey: {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "TLS version of the admin session, empty when it is not encrypted.",
+	},
+	serverInfoTLSCipherKey: {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "TLS cipher of the admin session, empty when it is not encrypted.",
+	},
+	serverInfoRolesSupportedKey: {
+		Type:     schema.TypeBool,
+		Computed: true,
+	},
+	serverInfoComponentsKey: {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "URNs of the installed components. Always empty on servers without components.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	serverInfoPluginsKey: {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Names of the active plugins.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+}
+
+func dataSourceServerInfoRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY dataSourceServerInfoRead()")
+	defer log.Println("[DEBUG] EXIT dataSourceServerInfoRead()")
+
+	cf := m.(connectionFactory)
+	dl, err := resolveDialect(cf.dialect)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	server := dl.server()
+
+	// Variables that do not exist on a flavor are missing from the result, and read as empty values
+	variables, err := queryNameValues(ctx, db, "SHOW GLOBAL VARIABLES WHERE Variable_name IN ('hostname', 'read_only', 'super_read_only', 'default_authentication_plugin', 'authentication_policy')")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading server variables: %w", err))
+	}
+
+	status, err := queryNameValues(ctx, db, "SHOW SESSION STATUS WHERE Variable_name IN ('Ssl_version', 'Ssl_cipher')")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading session status: %w", err))
+	}
+
+	plugins, err := queryStrings(ctx, db, "SELECT PLUGIN_NAME FROM information_schema.PLUGINS WHERE PLUGIN_STATUS = 'ACTIVE' ORDER BY PLUGIN_NAME")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading plugins: %w", err))
+	}
+
+	components := make([]string, 0)
+	if dl.supports(featureComponents) {
+		components, err = queryStrings(ctx, db, "SELECT component_urn FROM mysql.component ORDER BY component_urn")
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading components: %w", err))
+		}
+	}
+
+	values := map[string]any{
+		serverInfoVersionKey:           server.version.String(),
+		serverInfoVersionCommentKey:    server.versionComment,
+		serverInfoFlavorKey:            string(server.flavor),
+		serverInfoHostnameKey:          variables["hostname"],
+		serverInfoReadOnlyKey:          isEnabled(variables["read_only"]),
+		serverInfoSuperReadOnlyKey:     isEnabled(variables["super_read_only"]),
+		serverInfoDefaultAuthPluginKey: defaultAuthenticationPlugin(server, variables),
+		serverInfoTLSVersionKey:        status["Ssl_version"],
+		serverInfoTLSCipherKey:         status["Ssl_cipher"],
+		serverInfoRolesSupportedKey:    dl.supports(featureRoles),
+		serverInfoComponentsKey:        components,
+		serverInfoPluginsKey:           plugins,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", cf.host, cf.port))
+
+	return nil
+}
+
+// defaultAuthenticationPlugin works out the plugin used for new users. MySQL 8.4 replaced
+// default_authentication_plugin with authentication_policy, and MariaDB has neither.
+func defaultAuthenticationPlugin(server serverInfo, variables map[string]string) string {
+	if plugin := variables["default_authentication_plugin"]; plugin != "" {
+		return plugin
+	}
+
+	if policy, ok := variables["authentication_policy"]; ok {
+		first, _, _ := strings.Cut(policy, ",")
+		if first = strings.TrimPrefix(strings.TrimSpace(first), "*:"); first != "" && first != "*" {
+			return first
+		}
+		return "caching_sha2_password"
+	}
+
+	if server.flavor == flavorMariaDB {
+		return "mysql_native_password"
+	}
+
+	return ""
+}
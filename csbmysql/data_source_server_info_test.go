@@ -0,0 +1,37 @@
+package csbmysql_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Server info data source", func() {
+	It("reports the server the provider is connected to", func() {
+		dataSourceName := fmt.Sprintf("data.%s.server", csbmysql.DataSourceServerInfoNameKey)
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{{
+				Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+data "%s" "server" {}
+`, csbmysql.DataSourceServerInfoNameKey)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "flavor", "MySQL"),
+					resource.TestMatchResourceAttr(dataSourceName, "version", versionPrefixPattern()),
+					resource.TestCheckResourceAttr(dataSourceName, "read_only", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "super_read_only", "false"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "hostname"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "default_authentication_plugin"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "tls_version"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "tls_cipher"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "plugins.*", "InnoDB"),
+				),
+			}},
+		})
+	})
+})
@@ -0,0 +1,155 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	tablesDatabaseKey    = "database"
+	tablesNameRegexKey   = "name_regex"
+	tablesKey            = "tables"
+	tableNameKey         = "name"
+	tableTypeKey         = "type"
+	tableEngineKey       = "engine"
+	tableCharacterSetKey = "character_set"
+	tableCollationKey    = "collation"
+	tableRowsKey         = "rows"
+	tableDataLengthKey   = "data_length"
+	tableIndexLengthKey  = "index_length"
+)
+
+func DataSourceTables() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceTablesSchema,
+		ReadContext: dataSourceTablesRead,
+		Description: "Tables in a MySQL database with their engine, row estimate and size",
+	}
+}
+
+var dataSourceTablesSchema = map[string]*schema.Schema{
+	tablesDatabaseKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Database to list the tables of. Defaults to the database of the provider.",
+	},
+	tablesNameRegexKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsValidRegExp,
+		Description:  "Only return tables whose name matches this regular expression.",
+	},
+	tablesKey: {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				tableNameKey: {Type: schema.TypeString, Computed: true},
+				tableTypeKey: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "For example \"BASE TABLE\" or \"VIEW\".",
+				},
+				tableEngineKey:       {Type: schema.TypeString, Computed: true},
+				tableCharacterSetKey: {Type: schema.TypeString, Computed: true},
+				tableCollationKey:    {Type: schema.TypeString, Computed: true},
+				tableRowsKey: {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Estimated number of rows. Exact for MyISAM, approximate for InnoDB.",
+				},
+				tableDataLengthKey: {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "In bytes.",
+				},
+				tableIndexLengthKey: {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "In bytes.",
+				},
+			},
+		},
+	},
+}
+
+func dataSourceTablesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY dataSourceTablesRead()")
+	defer log.Println("[DEBUG] EXIT dataSourceTablesRead()")
+
+	cf := m.(connectionFactory)
+
+	database := d.Get(tablesDatabaseKey).(string)
+	if database == "" {
+		database = cf.database
+	}
+	nameRegex := d.Get(tablesNameRegexKey).(string)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	rows, err := db.QueryContext(ctx, `SELECT t.TABLE_NAME, t.TABLE_TYPE, t.ENGINE, c.CHARACTER_SET_NAME, t.TABLE_COLLATION,
+       t.TABLE_ROWS, t.DATA_LENGTH, t.INDEX_LENGTH
+FROM information_schema.TABLES t
+LEFT JOIN information_schema.COLLATION_CHARACTER_SET_APPLICABILITY c ON c.COLLATION_NAME = t.TABLE_COLLATION
+WHERE t.TABLE_SCHEMA = ?
+ORDER BY t.TABLE_NAME`, database)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing tables of %q: %w", database, err))
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	tables := make([]map[string]any, 0)
+	for rows.Next() {
+		var (
+			name, tableType                      string
+			engine, characterSet, collation      sql.NullString
+			rowEstimate, dataLength, indexLength sql.NullInt64
+		)
+		if err := rows.Scan(&name, &tableType, &engine, &characterSet, &collation, &rowEstimate, &dataLength, &indexLength); err != nil {
+			return diag.FromErr(fmt.Errorf("error listing tables of %q: %w", database, err))
+		}
+		tables = append(tables, map[string]any{
+			tableNameKey:         name,
+			tableTypeKey:         tableType,
+			tableEngineKey:       engine.String,
+			tableCharacterSetKey: characterSet.String,
+			tableCollationKey:    collation.String,
+			tableRowsKey:         rowEstimate.Int64,
+			tableDataLengthKey:   dataLength.Int64,
+			tableIndexLengthKey:  indexLength.Int64,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return diag.FromErr(fmt.Errorf("error listing tables of %q: %w", database, err))
+	}
+
+	filtered, err := filterByRegex(tables, tableNameKey, nameRegex)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(tablesKey, filtered); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(tablesDatabaseKey, database); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d/%s/tables?name_regex=%s", cf.host, cf.port, database, nameRegex))
+
+	return nil
+}
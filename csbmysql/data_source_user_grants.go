@@ -0,0 +1,188 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	userGrantsUsernameKey    = "username"
+	userGrantsHostKey        = "host"
+	userGrantsUsingRolesKey  = "using_roles"
+	userGrantsKey            = "grants"
+	grantKindKey             = "kind"
+	grantPrivilegesKey       = "privileges"
+	grantObjectTypeKey       = "object_type"
+	grantDatabaseKey         = "database"
+	grantTableKey            = "table"
+	grantColumnKey           = "column"
+	grantRolesKey            = "roles"
+	grantProxiedUserKey      = "proxied_user"
+	grantGrantOptionKey      = "grant_option"
+	userGrantsDefaultHostAll = "%"
+)
+
+func DataSourceUserGrants() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceUserGrantsSchema,
+		ReadContext: dataSourceUserGrantsRead,
+		Description: "Effective grants of a user, as reported by SHOW GRANTS",
+	}
+}
+
+var dataSourceUserGrantsSchema = map[string]*schema.Schema{
+	userGrantsUsernameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+	},
+	userGrantsHostKey: {
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  userGrantsDefaultHostAll,
+	},
+	userGrantsUsingRolesKey: {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Include the privileges of these roles, given as name or name@host. Requires MySQL 8 or TiDB, as MariaDB cannot show the grants of roles for a user.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	userGrantsKey: {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				grantKindKey: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "One of \"privilege\", \"role\" or \"proxy\".",
+				},
+				grantPrivilegesKey: {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				grantObjectTypeKey: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "One of \"TABLE\", \"PROCEDURE\" or \"FUNCTION\" for privilege grants.",
+				},
+				grantDatabaseKey: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Database the privileges apply to, \"*\" for global privileges.",
+				},
+				grantTableKey: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Table or routine the privileges apply to, \"*\" for database privileges.",
+				},
+				grantColumnKey: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Column the privileges apply to, empty unless they are column privileges.",
+				},
+				grantRolesKey: {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				grantProxiedUserKey: {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				grantGrantOptionKey: {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	},
+}
+
+func dataSourceUserGrantsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY dataSourceUserGrantsRead()")
+	defer log.Println("[DEBUG] EXIT dataSourceUserGrantsRead()")
+
+	username := d.Get(userGrantsUsernameKey).(string)
+	host := d.Get(userGrantsHostKey).(string)
+
+	var usingRoles []string
+	for _, role := range d.Get(userGrantsUsingRolesKey).([]any) {
+		usingRoles = append(usingRoles, role.(string))
+	}
+
+	cf := m.(connectionFactory)
+
+	if len(usingRoles) > 0 {
+		dl, err := resolveDialect(cf.dialect)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !dl.supports(featureShowGrantsUsing) {
+			return diag.Diagnostics{attributeError(cty.GetAttrPath(userGrantsUsingRolesKey), "Roles are not supported",
+				fmt.Sprintf("%s cannot show the grants of roles for a user, unset %s", dl.server(), userGrantsUsingRolesKey))}
+		}
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	grants, err := showGrants(ctx, db, username, host, usingRoles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := make([]map[string]any, 0, len(grants))
+	for _, g := range grants {
+		values = append(values, map[string]any{
+			grantKindKey:        g.kind,
+			grantPrivilegesKey:  g.privileges,
+			grantObjectTypeKey:  g.objectType,
+			grantDatabaseKey:    g.database,
+			grantTableKey:       g.table,
+			grantColumnKey:      g.column,
+			grantRolesKey:       g.roles,
+			grantProxiedUserKey: g.proxiedUser,
+			grantGrantOptionKey: g.grantOption,
+		})
+	}
+
+	if err := d.Set(userGrantsKey, values); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", username, host))
+
+	return nil
+}
+
+// showGrants returns the parsed grants of a user, including those of usingRoles if any
+func showGrants(ctx context.Context, db *sql.DB, username, host string, usingRoles []string) ([]grant, error) {
+	query := fmt.Sprintf("SHOW GRANTS FOR %s@%s", quotedString(username), quotedString(host))
+	if len(usingRoles) > 0 {
+		roles := make([]string, 0, len(usingRoles))
+		for _, role := range usingRoles {
+			roles = append(roles, quotedAccount(role))
+		}
+		query += " USING " + strings.Join(roles, ", ")
+	}
+
+	statements, err := queryStrings(ctx, db, query)
+	if err != nil {
+		return nil, fmt.Errorf("error reading grants for %q: %w", username, err)
+	}
+
+	return parseGrants(statements)
+}
@@ -0,0 +1,49 @@
+package csbmysql_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("User grants data source", func() {
+	It("returns the parsed grants of a binding user", func() {
+		const username = "grants-user"
+		dataSourceName := fmt.Sprintf("data.%s.grants", csbmysql.DataSourceUserGrantsNameKey)
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{{
+				Config: testGetDefinition(csbMySQLResource+fmt.Sprintf(`
+data "%s" "grants" {
+  username = %s.username
+}
+`, csbmysql.DataSourceUserGrantsNameKey, tfStateResourceName),
+					resourceDefinitionWithUsername(username),
+					resourceDefinitionWithPassword("grants-password"),
+					resourceDefinitionWithReadOnly(true),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "grants.*", map[string]string{
+						"kind":         "privilege",
+						"database":     "*",
+						"table":        "*",
+						"privileges.0": "USAGE",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "grants.*", map[string]string{
+						"kind":         "privilege",
+						"database":     database,
+						"table":        "*",
+						"privileges.0": "SELECT",
+						"grant_option": "false",
+					}),
+				),
+			}},
+		})
+	})
+})
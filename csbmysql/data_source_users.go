@@ -0,0 +1,211 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	usersNameRegexKey          = "name_regex"
+	usersHostKey               = "host"
+	usersKey                   = "users"
+	userNameKey                = "name"
+	userHostKey                = "host"
+	userPluginKey              = "plugin"
+	userSSLTypeKey             = "ssl_type"
+	userAccountLockedKey       = "account_locked"
+	userPasswordLastChangedKey = "password_last_changed"
+	userMaxQuestionsKey        = "max_questions"
+	userMaxUpdatesKey          = "max_updates"
+	userMaxConnectionsKey      = "max_connections"
+	userMaxUserConnectionsKey  = "max_user_connections"
+)
+
+// mysqlUserColumns maps attributes of a user to the mysql.user columns they are read from.
+// Not every flavor has every column, missing ones are read as empty values. Whether the
+// account is locked is read as the dialect tells, as MariaDB does not keep it in mysql.user.
+var mysqlUserColumns = []struct{ attribute, column string }{
+	{userNameKey, "User"},
+	{userHostKey, "Host"},
+	{userPluginKey, "plugin"},
+	{userSSLTypeKey, "ssl_type"},
+	{userAccountLockedKey, "account_locked"},
+	{userPasswordLastChangedKey, "password_last_changed"},
+	{userMaxQuestionsKey, "max_questions"},
+	{userMaxUpdatesKey, "max_updates"},
+	{userMaxConnectionsKey, "max_connections"},
+	{userMaxUserConnectionsKey, "max_user_connections"},
+}
+
+func DataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceUsersSchema,
+		ReadContext: dataSourceUsersRead,
+		Description: "Users on the MySQL server, as recorded in mysql.user",
+	}
+}
+
+var dataSourceUsersSchema = map[string]*schema.Schema{
+	usersNameRegexKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsValidRegExp,
+		Description:  "Only return users whose name matches this regular expression.",
+	},
+	usersHostKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Only return users with exactly this host.",
+	},
+	usersKey: {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				userNameKey:                {Type: schema.TypeString, Computed: true},
+				userHostKey:                {Type: schema.TypeString, Computed: true},
+				userPluginKey:              {Type: schema.TypeString, Computed: true},
+				userSSLTypeKey:             {Type: schema.TypeString, Computed: true},
+				userAccountLockedKey:       {Type: schema.TypeBool, Computed: true},
+				userPasswordLastChangedKey: {Type: schema.TypeString, Computed: true},
+				userMaxQuestionsKey:        {Type: schema.TypeInt, Computed: true},
+				userMaxUpdatesKey:          {Type: schema.TypeInt, Computed: true},
+				userMaxConnectionsKey:      {Type: schema.TypeInt, Computed: true},
+				userMaxUserConnectionsKey:  {Type: schema.TypeInt, Computed: true},
+			},
+		},
+	},
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY dataSourceUsersRead()")
+	defer log.Println("[DEBUG] EXIT dataSourceUsersRead()")
+
+	nameRegex := d.Get(usersNameRegexKey).(string)
+	host := d.Get(usersHostKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	dl, err := resolveDialect(cf.dialect)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	users, err := listUsers(ctx, db, dl, host)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filtered, err := filterByRegex(users, userNameKey, nameRegex)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(usersKey, filtered); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d/users?name_regex=%s&host=%s", cf.host, cf.port, nameRegex, host))
+
+	return nil
+}
+
+// listUsers reads the rows of mysql.user, optionally only those for one host.
+func listUsers(ctx context.Context, db *sql.DB, dl dialect, host string) ([]map[string]any, error) {
+	log.Println("[DEBUG] ENTRY listUsers()")
+	defer log.Println("[DEBUG] EXIT listUsers()")
+
+	available, err := tableColumns(ctx, db, "mysql", "user")
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]string, len(mysqlUserColumns))
+	for i, c := range mysqlUserColumns {
+		switch {
+		case c.attribute == userAccountLockedKey && dl.supports(featureAccountLocking):
+			selected[i] = dl.accountLockedExpression()
+		case c.attribute != userAccountLockedKey && available[strings.ToLower(c.column)]:
+			selected[i] = quotedIdentifier(c.column)
+		default:
+			selected[i] = "NULL"
+		}
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM mysql.user", strings.Join(selected, ", "))
+	var args []any
+	if host != "" {
+		query += " WHERE Host = ?"
+		args = append(args, host)
+	}
+	query += " ORDER BY User, Host"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	users := make([]map[string]any, 0)
+	for rows.Next() {
+		values := make([]sql.NullString, len(mysqlUserColumns))
+		pointers := make([]any, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("error listing users: %w", err)
+		}
+
+		user := make(map[string]any, len(mysqlUserColumns))
+		for i, c := range mysqlUserColumns {
+			user[c.attribute] = userAttributeValue(c.attribute, values[i].String)
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func userAttributeValue(attribute, value string) any {
+	switch attribute {
+	case userAccountLockedKey:
+		return value == "1"
+	case userMaxQuestionsKey, userMaxUpdatesKey, userMaxConnectionsKey, userMaxUserConnectionsKey:
+		var n int
+		_, _ = fmt.Sscan(value, &n)
+		return n
+	default:
+		return value
+	}
+}
+
+// tableColumns returns the lower-cased names of the columns of a table
+func tableColumns(ctx context.Context, db *sql.DB, schemaName, table string) (map[string]bool, error) {
+	names, err := queryStrings(ctx, db, "SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", schemaName, table)
+	if err != nil {
+		return nil, fmt.Errorf("error reading columns of %s.%s: %w", schemaName, table, err)
+	}
+
+	columns := make(map[string]bool, len(names))
+	for _, name := range names {
+		columns[strings.ToLower(name)] = true
+	}
+	return columns, nil
+}
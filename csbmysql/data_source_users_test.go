@@ -0,0 +1,37 @@
+package csbmysql_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Users data source", func() {
+	It("lists the users matching the filters", func() {
+		dataSourceName := fmt.Sprintf("data.%s.admins", csbmysql.DataSourceUsersNameKey)
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{{
+				Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+data "%s" "admins" {
+  name_regex = "^%s$"
+  host       = "localhost"
+}
+`, csbmysql.DataSourceUsersNameKey, adminUser)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "users.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.name", adminUser),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.host", "localhost"),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.account_locked", "false"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "users.0.plugin"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "users.0.password_last_changed"),
+				),
+			}},
+		})
+	})
+})
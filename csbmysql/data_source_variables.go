@@ -0,0 +1,113 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	variablesScopeKey     = "scope"
+	variablesNameLikeKey  = "name_like"
+	variablesNameRegexKey = "name_regex"
+	variablesKey          = "variables"
+	variablesScopeGlobal  = "global"
+	variablesScopeSession = "session"
+)
+
+func DataSourceVariables() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceVariablesSchema,
+		ReadContext: dataSourceVariablesRead,
+		Description: "Global or session system variables of the MySQL server, for checking prerequisites such as require_secure_transport or sql_mode",
+	}
+}
+
+var dataSourceVariablesSchema = map[string]*schema.Schema{
+	variablesScopeKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      variablesScopeGlobal,
+		ValidateFunc: validation.StringInSlice([]string{variablesScopeGlobal, variablesScopeSession}, false),
+		Description:  "Either \"global\" or \"session\". Session variables are those of the admin session.",
+	},
+	variablesNameLikeKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Only return variables whose name matches this SQL LIKE pattern, for example \"validate_password%\".",
+	},
+	variablesNameRegexKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsValidRegExp,
+		Description:  "Only return variables whose name matches this regular expression.",
+	},
+	variablesKey: {
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "Values of the variables, by name.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+}
+
+func dataSourceVariablesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY dataSourceVariablesRead()")
+	defer log.Println("[DEBUG] EXIT dataSourceVariablesRead()")
+
+	scope := d.Get(variablesScopeKey).(string)
+	nameLike := d.Get(variablesNameLikeKey).(string)
+	nameRegex := d.Get(variablesNameRegexKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	// SHOW VARIABLES reads the same data as performance_schema.global_variables and
+	// session_variables, but also works when the performance schema is disabled and on MariaDB
+	query := fmt.Sprintf("SHOW %s VARIABLES", strings.ToUpper(scope))
+	var args []any
+	if nameLike != "" {
+		query += " LIKE ?"
+		args = append(args, nameLike)
+	}
+
+	variables, err := queryNameValues(ctx, db, query, args...)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading %s variables: %w", scope, err))
+	}
+
+	names := make([]map[string]any, 0, len(variables))
+	for name := range variables {
+		names = append(names, map[string]any{"name": name})
+	}
+	matching, err := filterByRegex(names, "name", nameRegex)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filtered := make(map[string]string, len(matching))
+	for _, item := range matching {
+		name := item["name"].(string)
+		filtered[name] = variables[name]
+	}
+
+	if err := d.Set(variablesKey, filtered); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d/variables/%s?name_like=%s&name_regex=%s", cf.host, cf.port, scope, nameLike, nameRegex))
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package csbmysql_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Variables data source", func() {
+	It("reads the variables matching the filters", func() {
+		dataSourceName := fmt.Sprintf("data.%s.tls", csbmysql.DataSourceVariablesNameKey)
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{{
+				Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+data "%s" "tls" {
+  name_like  = "%%secure_transport"
+  name_regex = "^require_"
+}
+`, csbmysql.DataSourceVariablesNameKey)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "variables.%", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "variables.require_secure_transport", "OFF"),
+				),
+			}},
+		})
+	})
+})
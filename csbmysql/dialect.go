@@ -0,0 +1,430 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type serverFlavor string
+
+const (
+	flavorMySQL   serverFlavor = "MySQL"
+	flavorAurora  serverFlavor = "Aurora MySQL"
+	flavorMariaDB serverFlavor = "MariaDB"
+	flavorTiDB    serverFlavor = "TiDB"
+)
+
+// feature is something the provider can do on some servers but not on others.
+// The value is used in diagnostics, so it should read well in a sentence.
+type feature string
+
+const (
+	featureRoles            feature = "roles"
+	featureAWSRDSIAMUsers   feature = "AWS RDS IAM authenticated users"
+	featureCloudSQLIAMUsers feature = "Cloud SQL IAM authenticated users"
+	featureComponents       feature = "components"
+	featurePersistVariables feature = "persisted system variables"
+	featureProxyUsers       feature = "proxy users"
+	featureResourceGroups   feature = "resource groups"
+	featureAccountLocking   feature = "account locking"
+	featureDualPasswords    feature = "dual passwords"
+	featureShowGrantsUsing  feature = "grants of roles in SHOW GRANTS"
+)
+
+var versionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)`)
+
+type serverVersion struct {
+	major, minor, patch int
+}
+
+func (v serverVersion) atLeast(major, minor, patch int) bool {
+	if v.major != major {
+		return v.major > major
+	}
+	if v.minor != minor {
+		return v.minor > minor
+	}
+	return v.patch >= patch
+}
+
+func (v serverVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+func parseServerVersion(version string) (serverVersion, error) {
+	matches := versionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return serverVersion{}, fmt.Errorf("unable to parse server version %q", version)
+	}
+
+	var parts [3]int
+	for i := range parts {
+		parts[i], _ = strconv.Atoi(matches[i+1])
+	}
+
+	return serverVersion{major: parts[0], minor: parts[1], patch: parts[2]}, nil
+}
+
+// serverInfo describes the server the admin connection is talking to.
+// For TiDB the version is the TiDB release, not the MySQL version it emulates.
+type serverInfo struct {
+	flavor         serverFlavor
+	version        serverVersion
+	versionString  string
+	versionComment string
+}
+
+func (s serverInfo) String() string {
+	return fmt.Sprintf("%s %s", s.flavor, s.version)
+}
+
+func detectServer(ctx context.Context, db *sql.DB) (serverInfo, error) {
+	var version, comment string
+	if err := db.QueryRowContext(ctx, "SELECT VERSION(), @@version_comment").Scan(&version, &comment); err != nil {
+		return serverInfo{}, fmt.Errorf("error detecting server version: %w", err)
+	}
+
+	// Aurora reports the MySQL version it is compatible with, but has an extra variable
+	rows, err := db.QueryContext(ctx, "SHOW VARIABLES LIKE 'aurora_version'")
+	if err != nil {
+		return serverInfo{}, fmt.Errorf("error detecting server flavor: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	return parseServerInfo(version, comment, rows.Next())
+}
+
+func parseServerInfo(version, comment string, aurora bool) (serverInfo, error) {
+	info := serverInfo{
+		flavor:         flavorMySQL,
+		versionString:  version,
+		versionComment: comment,
+	}
+
+	versionNumber := version
+	switch {
+	case strings.Contains(version, "TiDB"):
+		info.flavor = flavorTiDB
+		if _, after, found := strings.Cut(version, "-TiDB-v"); found {
+			versionNumber = after
+		}
+	case strings.Contains(version, "MariaDB"), strings.Contains(comment, "MariaDB"):
+		info.flavor = flavorMariaDB
+		versionNumber = strings.TrimPrefix(version, "5.5.5-")
+	case aurora:
+		info.flavor = flavorAurora
+	}
+
+	v, err := parseServerVersion(versionNumber)
+	if err != nil {
+		return serverInfo{}, err
+	}
+	info.version = v
+
+	return info, nil
+}
+
+// dialect generates SQL statements for a particular server flavor and reports
+// which features are available, so that unsupported operations fail with a clear message
+// rather than a syntax error from the server.
+type dialect interface {
+	server() serverInfo
+	supports(f feature) bool
+	createUserStatement(username, host, identification string, tls tlsRequirement) string
+	grantStatement(privileges, database, username, host string) string
+	grantGlobalStatement(privileges, username, host string) string
+	revokeGlobalStatement(privileges, username, host string) string
+	grantObjectStatement(privileges, database, object, username, host string) string
+	revokeObjectStatement(privileges, database, object, username, host string) string
+	alterUserStatement(username, host, clause string) string
+	dropUserStatement(username, host string) string
+	accountLockedExpression() string
+}
+
+// tlsRequirement is the REQUIRE clause of CREATE USER and ALTER USER.
+// The zero value allows connections without TLS.
+type tlsRequirement struct {
+	ssl     bool
+	x509    bool
+	subject string
+	issuer  string
+	cipher  string
+}
+
+func (t tlsRequirement) clause() string {
+	var options []string
+	if t.subject != "" {
+		options = append(options, "SUBJECT "+quotedString(t.subject))
+	}
+	if t.issuer != "" {
+		options = append(options, "ISSUER "+quotedString(t.issuer))
+	}
+	if t.cipher != "" {
+		options = append(options, "CIPHER "+quotedString(t.cipher))
+	}
+
+	switch {
+	case len(options) > 0:
+		return "REQUIRE " + strings.Join(options, " AND ")
+	case t.x509:
+		return "REQUIRE X509"
+	case t.ssl:
+		return "REQUIRE SSL"
+	default:
+		return "REQUIRE NONE"
+	}
+}
+
+func newDialect(info serverInfo) dialect {
+	switch info.flavor {
+	case flavorMariaDB:
+		return mariaDBDialect{baseDialect{info: info}}
+	case flavorTiDB:
+		return tidbDialect{baseDialect{info: info}}
+	default:
+		return mysqlDialect{baseDialect{info: info}}
+	}
+}
+
+// requireFeature returns an error naming the server when the feature is not available on it,
+// or the error detecting the server.
+func requireFeature(d dialect, f feature) error {
+	d, err := resolveDialect(d)
+	if err != nil {
+		return err
+	}
+	if d.supports(f) {
+		return nil
+	}
+	return fmt.Errorf("%s unsupported on %s", f, d.server())
+}
+
+// serverDialect detects the server on first use rather than when the provider is configured, so that
+// the provider can be configured before the server is reachable, for example when it is created in the
+// same apply or the provider configuration is unknown at plan time. A failed detection is tried again
+// on next use, and until then statements are generated for MySQL.
+type serverDialect struct {
+	detect func(ctx context.Context) (serverInfo, error)
+
+	mutex    sync.Mutex
+	detected dialect
+}
+
+func newServerDialect(detect func(ctx context.Context) (serverInfo, error)) *serverDialect {
+	return &serverDialect{detect: detect}
+}
+
+func (s *serverDialect) resolve() (dialect, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.detected != nil {
+		return s.detected, nil
+	}
+	info, err := s.detect(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	s.detected = newDialect(info)
+	return s.detected, nil
+}
+
+func (s *serverDialect) current() dialect {
+	d, err := s.resolve()
+	if err != nil {
+		return mysqlDialect{}
+	}
+	return d
+}
+
+// resolveDialect returns the dialect of the detected server, or the error detecting it
+func resolveDialect(d dialect) (dialect, error) {
+	if s, ok := d.(*serverDialect); ok {
+		return s.resolve()
+	}
+	return d, nil
+}
+
+func (s *serverDialect) server() serverInfo      { return s.current().server() }
+func (s *serverDialect) supports(f feature) bool { return s.current().supports(f) }
+func (s *serverDialect) accountLockedExpression() string {
+	return s.current().accountLockedExpression()
+}
+
+func (s *serverDialect) createUserStatement(username, host, identification string, tls tlsRequirement) string {
+	return s.current().createUserStatement(username, host, identification, tls)
+}
+
+func (s *serverDialect) grantStatement(privileges, database, username, host string) string {
+	return s.current().grantStatement(privileges, database, username, host)
+}
+
+func (s *serverDialect) grantGlobalStatement(privileges, username, host string) string {
+	return s.current().grantGlobalStatement(privileges, username, host)
+}
+
+func (s *serverDialect) revokeGlobalStatement(privileges, username, host string) string {
+	return s.current().revokeGlobalStatement(privileges, username, host)
+}
+
+func (s *serverDialect) grantObjectStatement(privileges, database, object, username, host string) string {
+	return s.current().grantObjectStatement(privileges, database, object, username, host)
+}
+
+func (s *serverDialect) revokeObjectStatement(privileges, database, object, username, host string) string {
+	return s.current().revokeObjectStatement(privileges, database, object, username, host)
+}
+
+func (s *serverDialect) alterUserStatement(username, host, clause string) string {
+	return s.current().alterUserStatement(username, host, clause)
+}
+
+func (s *serverDialect) dropUserStatement(username, host string) string {
+	return s.current().dropUserStatement(username, host)
+}
+
+type baseDialect struct {
+	info serverInfo
+}
+
+func (b baseDialect) server() serverInfo {
+	return b.info
+}
+
+func (baseDialect) createUserStatement(username, host, identification string, tls tlsRequirement) string {
+	return fmt.Sprintf("CREATE USER %s@%s %s %s",
+		quotedIdentifier(username),
+		quotedIdentifier(host),
+		identification,
+		tls.clause(),
+	)
+}
+
+func (baseDialect) grantStatement(privileges, database, username, host string) string {
+	return fmt.Sprintf("GRANT %s ON %s.* TO %s@%s",
+		privileges,
+		quotedIdentifier(database),
+		quotedIdentifier(username),
+		quotedIdentifier(host))
+}
+
+func (baseDialect) grantGlobalStatement(privileges, username, host string) string {
+	return fmt.Sprintf("GRANT %s ON *.* TO %s@%s",
+		privileges,
+		quotedIdentifier(username),
+		quotedIdentifier(host))
+}
+
+func (baseDialect) revokeGlobalStatement(privileges, username, host string) string {
+	return fmt.Sprintf("REVOKE %s ON *.* FROM %s@%s",
+		privileges,
+		quotedIdentifier(username),
+		quotedIdentifier(host))
+}
+
+func (baseDialect) grantObjectStatement(privileges, database, object, username, host string) string {
+	return fmt.Sprintf("GRANT %s ON %s.%s TO %s@%s",
+		privileges,
+		quotedIdentifier(database),
+		quotedIdentifier(object),
+		quotedIdentifier(username),
+		quotedIdentifier(host))
+}
+
+func (baseDialect) revokeObjectStatement(privileges, database, object, username, host string) string {
+	return fmt.Sprintf("REVOKE %s ON %s.%s FROM %s@%s",
+		privileges,
+		quotedIdentifier(database),
+		quotedIdentifier(object),
+		quotedIdentifier(username),
+		quotedIdentifier(host))
+}
+
+func (baseDialect) alterUserStatement(username, host, clause string) string {
+	return fmt.Sprintf("ALTER USER %s@%s %s",
+		quotedIdentifier(username),
+		quotedIdentifier(host),
+		clause)
+}
+
+func (baseDialect) dropUserStatement(username, host string) string {
+	return fmt.Sprintf("DROP USER %s@%s", quotedString(username), quotedString(host))
+}
+
+// accountLockedExpression returns an expression over the columns of mysql.user for whether the account is locked
+func (baseDialect) accountLockedExpression() string {
+	return "account_locked = 'Y'"
+}
+
+// accountLockedQuery returns a query for whether the account given as user and host arguments is locked
+func accountLockedQuery(d dialect) string {
+	return fmt.Sprintf("SELECT %s FROM mysql.user WHERE User = ? AND Host = ?", d.accountLockedExpression())
+}
+
+// mysqlDialect covers MySQL 5.7, 8.x and Aurora MySQL
+type mysqlDialect struct {
+	baseDialect
+}
+
+func (m mysqlDialect) supports(f feature) bool {
+	switch f {
+	case featureRoles, featureShowGrantsUsing:
+		return m.info.version.atLeast(8, 0, 0)
+	case featureAWSRDSIAMUsers:
+		return true
+	case featureCloudSQLIAMUsers:
+		return m.info.flavor == flavorMySQL
+	case featureComponents:
+		return m.info.version.atLeast(8, 0, 0)
+	case featureProxyUsers, featureAccountLocking:
+		return true
+	case featureDualPasswords:
+		return m.info.version.atLeast(8, 0, 14)
+	case featureResourceGroups:
+		// Aurora MySQL does not support resource groups
+		return m.info.flavor == flavorMySQL && m.info.version.atLeast(8, 0, 0)
+	case featurePersistVariables:
+		// Aurora does not allow SET PERSIST, server settings are managed with parameter groups
+		return m.info.flavor == flavorMySQL && m.info.version.atLeast(8, 0, 0)
+	default:
+		return false
+	}
+}
+
+type mariaDBDialect struct {
+	baseDialect
+}
+
+func (m mariaDBDialect) supports(f feature) bool {
+	switch f {
+	case featureRoles:
+		return m.info.version.atLeast(10, 0, 5)
+	case featureAWSRDSIAMUsers, featureProxyUsers:
+		return true
+	case featureAccountLocking:
+		return m.info.version.atLeast(10, 4, 2)
+	default:
+		return false
+	}
+}
+
+// accountLockedExpression reads mysql.global_priv, as mysql.user is a view without account_locked since MariaDB 10.4
+func (mariaDBDialect) accountLockedExpression() string {
+	return "(SELECT COALESCE(JSON_VALUE(p.Priv, '$.account_locked'), 'false') = 'true' " +
+		"FROM mysql.global_priv p WHERE p.User = mysql.user.User AND p.Host = mysql.user.Host)"
+}
+
+type tidbDialect struct {
+	baseDialect
+}
+
+func (tidbDialect) supports(f feature) bool {
+	return f == featureRoles || f == featureAccountLocking || f == featureShowGrantsUsing
+}
@@ -0,0 +1,106 @@
+package csbmysql
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dialect", func() {
+	DescribeTable("detecting the server flavor and version",
+		func(version, comment string, aurora bool, expectedFlavor serverFlavor, expectedVersion string) {
+			info, err := parseServerInfo(version, comment, aurora)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.flavor).To(Equal(expectedFlavor))
+			Expect(info.version.String()).To(Equal(expectedVersion))
+		},
+		Entry("MySQL 8", "8.0.36", "MySQL Community Server - GPL", false, flavorMySQL, "8.0.36"),
+		Entry("MySQL 5.7", "5.7.44-log", "MySQL Community Server (GPL)", false, flavorMySQL, "5.7.44"),
+		Entry("Aurora MySQL", "8.0.28", "Source distribution", true, flavorAurora, "8.0.28"),
+		Entry("MariaDB", "10.11.6-MariaDB-1:10.11.6+maria~ubu2204", "mariadb.org binary distribution", false, flavorMariaDB, "10.11.6"),
+		Entry("MariaDB with replication prefix", "5.5.5-10.6.16-MariaDB", "MariaDB Server", false, flavorMariaDB, "10.6.16"),
+		Entry("TiDB", "8.0.11-TiDB-v7.5.0", "TiDB Server (Apache License 2.0) Community Edition, MySQL 8.0 compatible", false, flavorTiDB, "7.5.0"),
+	)
+
+	It("fails on an unparsable version", func() {
+		_, err := parseServerInfo("unknown", "", false)
+		Expect(err).To(MatchError(`unable to parse server version "unknown"`))
+	})
+
+	DescribeTable("feature support",
+		func(version string, f feature, expectedErr string) {
+			info, err := parseServerInfo(version, "", false)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = requireFeature(newDialect(info), f)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expectedErr))
+			}
+		},
+		Entry("roles on MySQL 8", "8.0.36", featureRoles, ""),
+		Entry("roles on MySQL 5.7", "5.7.44", featureRoles, "roles unsupported on MySQL 5.7.44"),
+		Entry("roles on MariaDB", "10.11.6-MariaDB", featureRoles, ""),
+		Entry("grants of roles on MariaDB", "10.11.6-MariaDB", featureShowGrantsUsing, "grants of roles in SHOW GRANTS unsupported on MariaDB 10.11.6"),
+		Entry("Cloud SQL IAM users on MariaDB", "10.11.6-MariaDB", featureCloudSQLIAMUsers, "Cloud SQL IAM authenticated users unsupported on MariaDB 10.11.6"),
+		Entry("AWS IAM users on TiDB", "8.0.11-TiDB-v7.5.0", featureAWSRDSIAMUsers, "AWS RDS IAM authenticated users unsupported on TiDB 7.5.0"),
+		Entry("resource groups on MySQL 5.7", "5.7.44", featureResourceGroups, "resource groups unsupported on MySQL 5.7.44"),
+		Entry("account locking on MariaDB 10.3", "10.3.39-MariaDB", featureAccountLocking, "account locking unsupported on MariaDB 10.3.39"),
+		Entry("dual passwords on MySQL 8.0.13", "8.0.13", featureDualPasswords, "dual passwords unsupported on MySQL 8.0.13"),
+		Entry("proxy users on TiDB", "8.0.11-TiDB-v7.5.0", featureProxyUsers, "proxy users unsupported on TiDB 7.5.0"),
+	)
+
+	DescribeTable("TLS requirement clause",
+		func(requirement tlsRequirement, expected string) {
+			Expect(requirement.clause()).To(Equal(expected))
+		},
+		Entry("none", tlsRequirement{}, "REQUIRE NONE"),
+		Entry("SSL", tlsRequirement{ssl: true}, "REQUIRE SSL"),
+		Entry("X509", tlsRequirement{ssl: true, x509: true}, "REQUIRE X509"),
+		Entry("subject and cipher", tlsRequirement{ssl: true, subject: "/CN=replica", cipher: "ECDHE-RSA-AES128-GCM-SHA256"},
+			"REQUIRE SUBJECT '/CN=replica' AND CIPHER 'ECDHE-RSA-AES128-GCM-SHA256'"),
+	)
+
+	It("reads whether a MariaDB account is locked from mysql.global_priv", func() {
+		info, err := parseServerInfo("10.11.6-MariaDB", "", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(accountLockedQuery(newDialect(info))).To(ContainSubstring("FROM mysql.global_priv"))
+
+		info, err = parseServerInfo("8.0.36", "", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(accountLockedQuery(newDialect(info))).To(Equal("SELECT account_locked = 'Y' FROM mysql.user WHERE User = ? AND Host = ?"))
+	})
+
+	Describe("detecting the server on first use", func() {
+		It("does not detect the server until the dialect is used", func() {
+			detections := 0
+			d := newServerDialect(func(context.Context) (serverInfo, error) {
+				detections++
+				return parseServerInfo("10.11.6-MariaDB", "", false)
+			})
+			Expect(detections).To(BeZero())
+
+			Expect(requireFeature(d, featureRoles)).To(Succeed())
+			Expect(d.server().flavor).To(Equal(flavorMariaDB))
+			Expect(detections).To(Equal(1))
+		})
+
+		It("returns the detection error and tries again on next use", func() {
+			reachable := false
+			d := newServerDialect(func(context.Context) (serverInfo, error) {
+				if !reachable {
+					return serverInfo{}, errors.New("connection refused")
+				}
+				return parseServerInfo("8.0.36", "", false)
+			})
+
+			Expect(requireFeature(d, featureRoles)).To(MatchError("connection refused"))
+
+			reachable = true
+			Expect(requireFeature(d, featureRoles)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,153 @@
+package csbmysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	adminCredentialsHostKey      = "host"
+	adminCredentialsPortKey      = "port"
+	adminCredentialsUsernameKey  = "username"
+	adminCredentialsPasswordKey  = "password"
+	adminCredentialsDatabaseKey  = "database"
+	adminCredentialsExpiresAtKey = "expires_at"
+)
+
+// adminCredentialsSource is the admin configuration of the provider. With IAM
+// authentication a new token is generated each time the credentials are opened.
+type adminCredentialsSource struct {
+	host     string
+	port     int
+	username string
+	password string
+	database string
+
+	awsRDSIAM *awsRDSIAMConfig
+	gcpIAM    *gcpIAMConfig
+}
+
+type awsRDSIAMConfig struct {
+	region  string
+	profile string
+}
+
+type gcpIAMConfig struct {
+	credentials string
+}
+
+// credentials returns the password or a new IAM token, and the time a token expires
+func (s *adminCredentialsSource) credentials(ctx context.Context) (string, time.Time, error) {
+	var (
+		generator authTokenGenerator
+		err       error
+	)
+	switch {
+	case s.awsRDSIAM != nil:
+		generator, err = newRDSTokenGenerator(ctx, s.host, s.port, s.awsRDSIAM.region, s.awsRDSIAM.profile, s.username)
+	case s.gcpIAM != nil:
+		generator, err = newGCPTokenGenerator(ctx, s.gcpIAM.credentials)
+	default:
+		return s.password, time.Time{}, nil
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	token, err := generator.GenerateToken(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token.value, token.expiry, nil
+}
+
+type ephemeralAdminCredentials struct {
+	source *adminCredentialsSource
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralAdminCredentials{}
+
+func NewEphemeralAdminCredentials() ephemeral.EphemeralResource {
+	return &ephemeralAdminCredentials{}
+}
+
+type ephemeralAdminCredentialsModel struct {
+	Host      types.String `tfsdk:"host"`
+	Port      types.Int64  `tfsdk:"port"`
+	Username  types.String `tfsdk:"username"`
+	Password  types.String `tfsdk:"password"`
+	Database  types.String `tfsdk:"database"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+func (e *ephemeralAdminCredentials) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = EphemeralAdminCredentialsNameKey
+}
+
+func (e *ephemeralAdminCredentials) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Credentials of the admin user of the provider, for passing to other providers or write-only attributes " +
+			"without storing them in the plan or state. With IAM authentication the password is a new token.",
+		Attributes: map[string]schema.Attribute{
+			adminCredentialsHostKey:     schema.StringAttribute{Computed: true},
+			adminCredentialsPortKey:     schema.Int64Attribute{Computed: true},
+			adminCredentialsUsernameKey: schema.StringAttribute{Computed: true},
+			adminCredentialsPasswordKey: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Password of the admin user, or an IAM authentication token.",
+			},
+			adminCredentialsDatabaseKey: schema.StringAttribute{Computed: true},
+			adminCredentialsExpiresAtKey: schema.StringAttribute{
+				Computed:    true,
+				Description: "Time an IAM authentication token expires, in RFC 3339 format. Null for a password.",
+			},
+		},
+	}
+}
+
+func (e *ephemeralAdminCredentials) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// ProviderData is nil until the provider has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	source, ok := req.ProviderData.(*adminCredentialsSource)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *adminCredentialsSource, got %T", req.ProviderData))
+		return
+	}
+	e.source = source
+}
+
+func (e *ephemeralAdminCredentials) Open(ctx context.Context, _ ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if e.source == nil {
+		resp.Diagnostics.AddError("Provider not configured", "the admin credentials are only available once the provider is configured")
+		return
+	}
+
+	password, expiry, err := e.source.credentials(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error generating admin credentials", err.Error())
+		return
+	}
+
+	result := ephemeralAdminCredentialsModel{
+		Host:      types.StringValue(e.source.host),
+		Port:      types.Int64Value(int64(e.source.port)),
+		Username:  types.StringValue(e.source.username),
+		Password:  types.StringValue(password),
+		Database:  types.StringValue(e.source.database),
+		ExpiresAt: types.StringNull(),
+	}
+	if !expiry.IsZero() {
+		result.ExpiresAt = types.StringValue(expiry.UTC().Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
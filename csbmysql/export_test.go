@@ -0,0 +1,7 @@
+package csbmysql
+
+// EnsureSecureTransport is exported for the tests that need a server
+var EnsureSecureTransport = ensureSecureTransport
+
+// EventSchedulerWarning is exported for the tests that need a server
+var EventSchedulerWarning = eventSchedulerWarning
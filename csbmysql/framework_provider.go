@@ -0,0 +1,169 @@
+package csbmysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+const providerTypeName = "csbmysql"
+
+// NewProviderServer serves Provider together with the plugin framework provider,
+// which has the ephemeral resources that the SDK does not support
+func NewProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	server, err := tf5muxserver.NewMuxServer(ctx,
+		Provider().GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error combining providers: %w", err)
+	}
+	return server.ProviderServer, nil
+}
+
+// frameworkProvider only has ephemeral resources. The mux server requires its schema
+// to be the same as the one of Provider, so ProviderSchema changes must be made here too.
+// The mux server does not compare MaxItems, which blocks get from validators here.
+type frameworkProvider struct{}
+
+var _ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+func NewFrameworkProvider() provider.Provider {
+	return &frameworkProvider{}
+}
+
+type frameworkProviderModel struct {
+	Host                   types.String              `tfsdk:"host"`
+	Port                   types.Int64               `tfsdk:"port"`
+	Username               types.String              `tfsdk:"username"`
+	Password               types.String              `tfsdk:"password"`
+	Database               types.String              `tfsdk:"database"`
+	SSLRootCert            types.String              `tfsdk:"sslrootcert"`
+	SSLCert                types.String              `tfsdk:"sslcert"`
+	SSLKey                 types.String              `tfsdk:"sslkey"`
+	SkipVerify             types.Bool                `tfsdk:"skip_verify"`
+	RequireSecureTransport types.Bool                `tfsdk:"require_secure_transport"`
+	AWSRDSIAM              []frameworkAWSRDSIAMModel `tfsdk:"aws_rds_iam"`
+	GCPIAM                 []frameworkGCPIAMModel    `tfsdk:"gcp_iam"`
+}
+
+type frameworkAWSRDSIAMModel struct {
+	Region  types.String `tfsdk:"region"`
+	Profile types.String `tfsdk:"profile"`
+}
+
+type frameworkGCPIAMModel struct {
+	Credentials types.String `tfsdk:"credentials"`
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = providerTypeName
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			hostKey:     schema.StringAttribute{Required: true},
+			portKey:     schema.Int64Attribute{Required: true},
+			usernameKey: schema.StringAttribute{Required: true},
+			passwordKey: schema.StringAttribute{Optional: true, Sensitive: true},
+			databaseKey: schema.StringAttribute{Required: true},
+
+			sslRootCertKey: schema.StringAttribute{Optional: true},
+			sslCertKey:     schema.StringAttribute{Optional: true},
+			sslKeyKey:      schema.StringAttribute{Optional: true},
+
+			skipVerifyKey: schema.BoolAttribute{
+				Optional:    true,
+				Description: skipVerifyDescription,
+			},
+			requireSecureTransportKey: schema.BoolAttribute{
+				Optional:    true,
+				Description: requireSecureTransportDescription,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			awsRDSIAMKey: schema.ListNestedBlock{
+				Description: awsRDSIAMDescription,
+				Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						awsRegionKey: schema.StringAttribute{Required: true},
+						awsProfileKey: schema.StringAttribute{
+							Optional:    true,
+							Description: awsProfileDescription,
+						},
+					},
+				},
+			},
+			gcpIAMKey: schema.ListNestedBlock{
+				Description: gcpIAMDescription,
+				Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						gcpCredentialsKey: schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: gcpCredentialsDescription,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure does not connect, as the credentials are only needed once an ephemeral resource is opened
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := &adminCredentialsSource{
+		host:     config.Host.ValueString(),
+		port:     int(config.Port.ValueInt64()),
+		username: config.Username.ValueString(),
+		password: config.Password.ValueString(),
+		database: config.Database.ValueString(),
+	}
+	if len(config.AWSRDSIAM) > 0 {
+		source.awsRDSIAM = &awsRDSIAMConfig{
+			region:  config.AWSRDSIAM[0].Region.ValueString(),
+			profile: config.AWSRDSIAM[0].Profile.ValueString(),
+		}
+	}
+	if len(config.GCPIAM) > 0 {
+		source.gcpIAM = &gcpIAMConfig{
+			credentials: config.GCPIAM[0].Credentials.ValueString(),
+		}
+	}
+
+	resp.EphemeralResourceData = source
+}
+
+func (p *frameworkProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewEphemeralAdminCredentials,
+	}
+}
+
+func (p *frameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
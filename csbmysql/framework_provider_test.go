@@ -0,0 +1,57 @@
+package csbmysql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewProviderServer", func() {
+	It("serves the resources of both providers, which have the same provider schema", func() {
+		ctx := context.Background()
+		server, err := NewProviderServer(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := server().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(resp.ResourceSchemas).To(HaveKey(ResourceNameKey))
+		Expect(resp.EphemeralResourceSchemas).To(HaveKey(EphemeralAdminCredentialsNameKey))
+	})
+
+	It("accepts at most one aws_rds_iam block, like Provider", func() {
+		ctx := context.Background()
+		server := providerserver.NewProtocol5(NewFrameworkProvider())()
+
+		schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		configType := schemaResp.Provider.ValueType().(tftypes.Object)
+
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range configType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		blockType := configType.AttributeTypes[awsRDSIAMKey].(tftypes.List)
+		block := tftypes.NewValue(blockType.ElementType, map[string]tftypes.Value{
+			awsRegionKey:  tftypes.NewValue(tftypes.String, "us-east-1"),
+			awsProfileKey: tftypes.NewValue(tftypes.String, nil),
+		})
+		values[hostKey] = tftypes.NewValue(tftypes.String, "localhost")
+		values[portKey] = tftypes.NewValue(tftypes.Number, 3306)
+		values[usernameKey] = tftypes.NewValue(tftypes.String, "admin")
+		values[databaseKey] = tftypes.NewValue(tftypes.String, "mysql")
+		values[awsRDSIAMKey] = tftypes.NewValue(blockType, []tftypes.Value{block, block})
+		values[gcpIAMKey] = tftypes.NewValue(configType.AttributeTypes[gcpIAMKey], []tftypes.Value{})
+
+		config, err := tfprotov5.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := server.PrepareProviderConfig(ctx, &tfprotov5.PrepareProviderConfigRequest{Config: &config})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Diagnostics).To(HaveExactElements(HaveField("Detail", ContainSubstring("must contain at most 1 elements"))))
+	})
+})
@@ -0,0 +1,426 @@
+package csbmysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	grantKindPrivilege = "privilege"
+	grantKindRole      = "role"
+	grantKindProxy     = "proxy"
+
+	grantObjectTable = "TABLE"
+)
+
+// grant is one statement from the output of SHOW GRANTS. Column level privileges
+// are split into one grant per column, so that every grant has a single scope.
+type grant struct {
+	kind        string
+	privileges  []string
+	objectType  string
+	database    string
+	table       string
+	column      string
+	roles       []string
+	proxiedUser string
+	grantOption bool
+}
+
+// parseGrants parses the rows returned by SHOW GRANTS
+func parseGrants(statements []string) ([]grant, error) {
+	result := make([]grant, 0, len(statements))
+	for _, statement := range statements {
+		grants, err := parseGrant(statement)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, grants...)
+	}
+	return result, nil
+}
+
+// parseGrant parses a single GRANT statement in the form produced by SHOW GRANTS
+func parseGrant(statement string) ([]grant, error) {
+	tokens, err := tokenizeGrant(statement)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse grant %q: %w", statement, err)
+	}
+
+	p := grantParser{tokens: tokens}
+	grants, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse grant %q: %w", statement, err)
+	}
+	return grants, nil
+}
+
+type grantTokenKind int
+
+const (
+	grantTokenWord grantTokenKind = iota
+	grantTokenQuoted
+	grantTokenSymbol
+)
+
+type grantToken struct {
+	kind  grantTokenKind
+	value string
+}
+
+func (t grantToken) isWord(word string) bool {
+	return t.kind == grantTokenWord && strings.EqualFold(t.value, word)
+}
+
+func (t grantToken) isSymbol(symbol string) bool {
+	return t.kind == grantTokenSymbol && t.value == symbol
+}
+
+// tokenizeGrant splits a statement into words, quoted identifiers or strings and symbols.
+// Quotes are removed from quoted tokens, and doubled quote characters are collapsed.
+func tokenizeGrant(statement string) ([]grantToken, error) {
+	var tokens []grantToken
+	runes := []rune(statement)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '`' || r == '\'' || r == '"':
+			var value strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated quoted value")
+				}
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						value.WriteRune(r)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				if runes[i] == '\\' && r != '`' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, grantToken{kind: grantTokenQuoted, value: value.String()})
+		case strings.ContainsRune("(),.@*;", r):
+			tokens = append(tokens, grantToken{kind: grantTokenSymbol, value: string(r)})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r`'\"(),.@*;", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, grantToken{kind: grantTokenWord, value: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+type grantParser struct {
+	tokens   []grantToken
+	position int
+}
+
+func (p *grantParser) peek() (grantToken, bool) {
+	if p.position >= len(p.tokens) {
+		return grantToken{}, false
+	}
+	return p.tokens[p.position], true
+}
+
+func (p *grantParser) next() (grantToken, error) {
+	t, ok := p.peek()
+	if !ok {
+		return grantToken{}, fmt.Errorf("unexpected end of statement")
+	}
+	p.position++
+	return t, nil
+}
+
+func (p *grantParser) expectWord(word string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if !t.isWord(word) {
+		return fmt.Errorf("expected %s but found %q", word, t.value)
+	}
+	return nil
+}
+
+type privilegeItem struct {
+	name    string
+	columns []string
+}
+
+func (p *grantParser) parse() ([]grant, error) {
+	if err := p.expectWord("GRANT"); err != nil {
+		return nil, err
+	}
+
+	// Role grants have no ON clause: GRANT `r1`@`%`,`r2`@`%` TO `u`@`%`
+	if p.isRoleGrant() {
+		return p.parseRoleGrant()
+	}
+
+	items, err := p.parsePrivileges()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectWord("ON"); err != nil {
+		return nil, err
+	}
+
+	if len(items) == 1 && strings.EqualFold(items[0].name, "PROXY") {
+		proxied, err := p.parseAccount()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.parseGrantee(); err != nil {
+			return nil, err
+		}
+		return []grant{{
+			kind:        grantKindProxy,
+			privileges:  []string{"PROXY"},
+			proxiedUser: proxied,
+			grantOption: p.hasGrantOption(),
+		}}, nil
+	}
+
+	objectType := grantObjectTable
+	if t, ok := p.peek(); ok && (t.isWord("TABLE") || t.isWord("PROCEDURE") || t.isWord("FUNCTION")) {
+		objectType = strings.ToUpper(t.value)
+		p.position++
+	}
+
+	database, table, err := p.parseObject()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.parseGrantee(); err != nil {
+		return nil, err
+	}
+	grantOption := p.hasGrantOption()
+
+	newGrant := func(column string) grant {
+		return grant{
+			kind:        grantKindPrivilege,
+			objectType:  objectType,
+			database:    database,
+			table:       table,
+			column:      column,
+			grantOption: grantOption,
+		}
+	}
+
+	var (
+		result  []grant
+		columns []string
+		byCol   = make(map[string]*grant)
+	)
+	for _, item := range items {
+		if len(item.columns) == 0 {
+			if len(result) == 0 {
+				result = append(result, newGrant(""))
+			}
+			result[0].privileges = append(result[0].privileges, item.name)
+			continue
+		}
+		for _, column := range item.columns {
+			if _, ok := byCol[column]; !ok {
+				g := newGrant(column)
+				byCol[column] = &g
+				columns = append(columns, column)
+			}
+			byCol[column].privileges = append(byCol[column].privileges, item.name)
+		}
+	}
+	for _, column := range columns {
+		result = append(result, *byCol[column])
+	}
+
+	return result, nil
+}
+
+func (p *grantParser) isRoleGrant() bool {
+	for _, t := range p.tokens[p.position:] {
+		if t.isWord("ON") {
+			return false
+		}
+		if t.isWord("TO") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *grantParser) parseRoleGrant() ([]grant, error) {
+	var roles []string
+	for {
+		role, err := p.parseAccount()
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+
+		t, ok := p.peek()
+		if !ok || !t.isSymbol(",") {
+			break
+		}
+		p.position++
+	}
+
+	if err := p.parseGrantee(); err != nil {
+		return nil, err
+	}
+
+	return []grant{{kind: grantKindRole, roles: roles, grantOption: p.hasGrantOption()}}, nil
+}
+
+// parsePrivileges reads a comma separated list of privileges, each of which may be several
+// words long (CREATE TEMPORARY TABLES) and may be followed by a list of columns.
+func (p *grantParser) parsePrivileges() ([]privilegeItem, error) {
+	var (
+		items []privilegeItem
+		words []string
+	)
+	for {
+		t, ok := p.peek()
+		if !ok {
+			return nil, fmt.Errorf("unexpected end of statement")
+		}
+
+		switch {
+		case t.isWord("ON"):
+			if len(words) > 0 {
+				items = append(items, privilegeItem{name: strings.Join(words, " ")})
+			}
+			if len(items) == 0 {
+				return nil, fmt.Errorf("missing privilege")
+			}
+			return items, nil
+		case t.isSymbol(","):
+			p.position++
+			if len(words) > 0 {
+				items = append(items, privilegeItem{name: strings.Join(words, " ")})
+				words = nil
+			}
+		case t.isSymbol("("):
+			p.position++
+			columns, err := p.parseColumns()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, privilegeItem{name: strings.Join(words, " "), columns: columns})
+			words = nil
+		case t.kind == grantTokenWord:
+			p.position++
+			words = append(words, strings.ToUpper(t.value))
+		default:
+			return nil, fmt.Errorf("unexpected %q in privilege list", t.value)
+		}
+	}
+}
+
+func (p *grantParser) parseColumns() ([]string, error) {
+	var columns []string
+	for {
+		t, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case t.isSymbol(")"):
+			return columns, nil
+		case t.isSymbol(","):
+		case t.kind == grantTokenWord || t.kind == grantTokenQuoted:
+			columns = append(columns, t.value)
+		default:
+			return nil, fmt.Errorf("unexpected %q in column list", t.value)
+		}
+	}
+}
+
+// parseObject reads the `db`.`table` part of the ON clause, where either may be *
+func (p *grantParser) parseObject() (string, string, error) {
+	database, err := p.parseObjectName()
+	if err != nil {
+		return "", "", err
+	}
+
+	t, ok := p.peek()
+	if !ok || !t.isSymbol(".") {
+		return "", "", fmt.Errorf("expected database.table")
+	}
+	p.position++
+
+	table, err := p.parseObjectName()
+	if err != nil {
+		return "", "", err
+	}
+
+	return database, table, nil
+}
+
+func (p *grantParser) parseObjectName() (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if t.isSymbol("*") || t.kind == grantTokenWord || t.kind == grantTokenQuoted {
+		return t.value, nil
+	}
+	return "", fmt.Errorf("unexpected %q in object name", t.value)
+}
+
+// parseAccount reads user@host, returned in the same form
+func (p *grantParser) parseAccount() (string, error) {
+	user, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if user.kind == grantTokenSymbol {
+		return "", fmt.Errorf("unexpected %q in account name", user.value)
+	}
+
+	t, ok := p.peek()
+	if !ok || !t.isSymbol("@") {
+		return user.value, nil
+	}
+	p.position++
+
+	host, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s@%s", user.value, host.value), nil
+}
+
+func (p *grantParser) parseGrantee() error {
+	if err := p.expectWord("TO"); err != nil {
+		return err
+	}
+	_, err := p.parseAccount()
+	return err
+}
+
+// hasGrantOption looks for WITH GRANT OPTION, or WITH ADMIN OPTION for roles, among the
+// remaining clauses, which can include others such as MariaDB's IDENTIFIED BY PASSWORD
+func (p *grantParser) hasGrantOption() bool {
+	rest := p.tokens[p.position:]
+	for i := 0; i+2 < len(rest); i++ {
+		if rest[i].isWord("WITH") && (rest[i+1].isWord("GRANT") || rest[i+1].isWord("ADMIN")) && rest[i+2].isWord("OPTION") {
+			return true
+		}
+	}
+	return false
+}
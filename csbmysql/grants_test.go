@@ -0,0 +1,56 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Grants parser", func() {
+	DescribeTable("parsing SHOW GRANTS output",
+		func(statement string, expected []grant) {
+			Expect(parseGrant(statement)).To(Equal(expected))
+		},
+		Entry("global usage", "GRANT USAGE ON *.* TO `some-user`@`%`",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"USAGE"}, objectType: "TABLE", database: "*", table: "*"}},
+		),
+		Entry("database privileges", "GRANT ALL PRIVILEGES ON `nuclear-flux`.* TO `some-user`@`%`",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"ALL PRIVILEGES"}, objectType: "TABLE", database: "nuclear-flux", table: "*"}},
+		),
+		Entry("dynamic privileges with grant option", "GRANT BACKUP_ADMIN,BINLOG_ADMIN ON *.* TO `root`@`localhost` WITH GRANT OPTION",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"BACKUP_ADMIN", "BINLOG_ADMIN"}, objectType: "TABLE", database: "*", table: "*", grantOption: true}},
+		),
+		Entry("multi-word privileges on a table", "GRANT SELECT, CREATE TEMPORARY TABLES ON `db`.`t` TO 'u'@'%'",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"SELECT", "CREATE TEMPORARY TABLES"}, objectType: "TABLE", database: "db", table: "t"}},
+		),
+		Entry("column privileges", "GRANT SELECT (`a`, `b`), INSERT (`a`), UPDATE ON `db`.`t` TO `u`@`%`",
+			[]grant{
+				{kind: grantKindPrivilege, privileges: []string{"UPDATE"}, objectType: "TABLE", database: "db", table: "t"},
+				{kind: grantKindPrivilege, privileges: []string{"SELECT", "INSERT"}, objectType: "TABLE", database: "db", table: "t", column: "a"},
+				{kind: grantKindPrivilege, privileges: []string{"SELECT"}, objectType: "TABLE", database: "db", table: "t", column: "b"},
+			},
+		),
+		Entry("only column privileges", "GRANT SELECT (`a`) ON `db`.`t` TO `u`@`%`",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"SELECT"}, objectType: "TABLE", database: "db", table: "t", column: "a"}},
+		),
+		Entry("routine privileges", "GRANT EXECUTE ON PROCEDURE `db`.`housekeeping` TO `u`@`%`",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"EXECUTE"}, objectType: "PROCEDURE", database: "db", table: "housekeeping"}},
+		),
+		Entry("roles", "GRANT `reader`@`%`,`writer`@`%` TO `u`@`%` WITH ADMIN OPTION",
+			[]grant{{kind: grantKindRole, roles: []string{"reader@%", "writer@%"}, grantOption: true}},
+		),
+		Entry("proxy", "GRANT PROXY ON ``@`` TO `root`@`localhost` WITH GRANT OPTION",
+			[]grant{{kind: grantKindProxy, privileges: []string{"PROXY"}, proxiedUser: "@", grantOption: true}},
+		),
+		Entry("MariaDB with password clause", "GRANT ALL PRIVILEGES ON *.* TO `root`@`localhost` IDENTIFIED BY PASSWORD '*81F5E21E35407D884A6CD4A731AEBFB6AF209E1B' WITH GRANT OPTION",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"ALL PRIVILEGES"}, objectType: "TABLE", database: "*", table: "*", grantOption: true}},
+		),
+		Entry("escaped wildcard database", "GRANT SELECT ON `my\\_db`.* TO `u`@`%`",
+			[]grant{{kind: grantKindPrivilege, privileges: []string{"SELECT"}, objectType: "TABLE", database: "my\\_db", table: "*"}},
+		),
+	)
+
+	It("fails on statements that are not grants", func() {
+		_, err := parseGrant("REVOKE SELECT ON *.* FROM `u`@`%`")
+		Expect(err).To(MatchError(ContainSubstring("expected GRANT")))
+	})
+})
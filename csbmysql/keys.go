@@ -11,4 +11,31 @@ const (
 	sslCertKey      = "sslcert"
 	sslKeyKey       = "sslkey"
 	skipVerifyKey   = "skip_verify"
+
+	requireSecureTransportKey = "require_secure_transport"
+
+	awsRDSIAMKey      = "aws_rds_iam"
+	awsRegionKey      = "region"
+	awsProfileKey     = "profile"
+	gcpIAMKey         = "gcp_iam"
+	gcpCredentialsKey = "credentials"
+
+	ResourceGlobalVariableNameKey     = "csbmysql_global_variable"
+	ResourceReplicationUserNameKey    = "csbmysql_replication_user"
+	ResourceProxyGrantNameKey         = "csbmysql_proxy_grant"
+	ResourceRoutineNameKey            = "csbmysql_routine"
+	ResourceViewNameKey               = "csbmysql_view"
+	ResourceEventNameKey              = "csbmysql_event"
+	ResourceAuditLogFilterNameKey     = "csbmysql_audit_log_filter"
+	ResourceAuditLogFilterUserNameKey = "csbmysql_audit_log_filter_user"
+	ResourceResourceGroupNameKey      = "csbmysql_resource_group"
+
+	DataSourceServerInfoNameKey = "csbmysql_server_info"
+	DataSourceUsersNameKey      = "csbmysql_users"
+	DataSourceUserGrantsNameKey = "csbmysql_user_grants"
+	DataSourceDatabasesNameKey  = "csbmysql_databases"
+	DataSourceTablesNameKey     = "csbmysql_tables"
+	DataSourceVariablesNameKey  = "csbmysql_variables"
+
+	EphemeralAdminCredentialsNameKey = "csbmysql_admin_credentials"
 )
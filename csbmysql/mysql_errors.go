@@ -0,0 +1,80 @@
+package csbmysql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	// errorDBAccessDenied is ER_DBACCESS_DENIED_ERROR
+	errorDBAccessDenied = 1044
+	// errorAccessDenied is ER_ACCESS_DENIED_ERROR, returned when logging in fails
+	errorAccessDenied = 1045
+	// errorSpecificAccessDenied is ER_SPECIFIC_ACCESS_DENIED_ERROR, returned when a privilege such as CREATE USER is missing
+	errorSpecificAccessDenied = 1227
+	// errorCannotUser is ER_CANNOT_USER, returned by CREATE USER for a user that exists and by DROP USER for one that does not
+	errorCannotUser = 1396
+	// errorInsecureTransport is ER_SECURE_TRANSPORT_REQUIRED
+	errorInsecureTransport = 3159
+)
+
+// mysqlErrorHint explains a MySQL error that is usually caused by the configuration
+type mysqlErrorHint struct {
+	summary string
+	fix     string
+}
+
+var mysqlErrorHints = map[uint16]mysqlErrorHint{
+	errorDBAccessDenied: {
+		summary: "Admin user cannot access the database",
+		fix:     "Grant the admin user of the provider ALL PRIVILEGES on the database WITH GRANT OPTION, or check the database of the provider.",
+	},
+	errorAccessDenied: {
+		summary: "Admin user cannot log in",
+		fix:     "Check the username and password of the provider, or for IAM authentication that the user is set up for it and the token is for the right host.",
+	},
+	errorSpecificAccessDenied: {
+		summary: "Admin user is missing a privilege",
+		fix:     "Grant the admin user of the provider the privilege named in the error, such as CREATE USER, SYSTEM_USER or CONNECTION_ADMIN.",
+	},
+	errorCannotUser: {
+		summary: "Operation failed for the user",
+		fix:     "The user was probably created or dropped outside of Terraform. Check whether it exists, or choose another username.",
+	},
+	errorNotValidPassword: {
+		summary: "Password does not satisfy the validate_password policy",
+		fix:     "Choose a password that meets the policy of the server, shown by SHOW VARIABLES LIKE 'validate_password%', or use password_generation.",
+	},
+	errorInsecureTransport: {
+		summary: "Server requires TLS",
+		fix:     "The server has require_secure_transport ON, so the provider must connect with TLS. Check the TLS settings of the provider, such as sslrootcert.",
+	},
+}
+
+// mysqlErrorDiagnostics returns the diagnostics for an error, with a summary and a suggested fix for the
+// MySQL errors in mysqlErrorHints. attributes maps error numbers to the attribute of the resource that caused
+// them, as some errors, like an access denied for the admin user, are caused by the provider configuration.
+func mysqlErrorDiagnostics(err error, attributes map[uint16]string) diag.Diagnostics {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return diag.FromErr(err)
+	}
+	hint, ok := mysqlErrorHints[mysqlErr.Number]
+	if !ok {
+		return diag.FromErr(err)
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  hint.summary,
+		Detail:   fmt.Sprintf("%s\n\n%s", err, hint.fix),
+	}
+	if attribute, ok := attributes[mysqlErr.Number]; ok {
+		diagnostic.AttributePath = cty.GetAttrPath(attribute)
+	}
+	return diag.Diagnostics{diagnostic}
+}
@@ -0,0 +1,53 @@
+package csbmysql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("mysqlErrorDiagnostics", func() {
+	attributes := map[uint16]string{errorCannotUser: "username"}
+
+	It("explains a known error and points at the attribute that caused it", func() {
+		err := fmt.Errorf("error creating user: %w", &mysql.MySQLError{Number: errorCannotUser, Message: "Operation CREATE USER failed for 'app'@'%'"})
+
+		diags := mysqlErrorDiagnostics(err, attributes)
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Severity).To(Equal(diag.Error))
+		Expect(diags[0].Summary).To(Equal("Operation failed for the user"))
+		Expect(diags[0].Detail).To(ContainSubstring("Operation CREATE USER failed"))
+		Expect(diags[0].Detail).To(ContainSubstring("choose another username"))
+		Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("username")))
+	})
+
+	It("has no attribute for errors caused by the provider configuration", func() {
+		diags := mysqlErrorDiagnostics(&mysql.MySQLError{Number: errorSpecificAccessDenied, Message: "you need the CREATE USER privilege"}, attributes)
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Summary).To(Equal("Admin user is missing a privilege"))
+		Expect(diags[0].AttributePath).To(BeNil())
+	})
+
+	DescribeTable("recognizes common errors",
+		func(number uint16) {
+			diags := mysqlErrorDiagnostics(&mysql.MySQLError{Number: number}, nil)
+			Expect(diags[0].Detail).To(ContainSubstring(mysqlErrorHints[number].fix))
+		},
+		Entry("access denied", uint16(errorAccessDenied)),
+		Entry("database access denied", uint16(errorDBAccessDenied)),
+		Entry("missing privilege", uint16(errorSpecificAccessDenied)),
+		Entry("operation failed for user", uint16(errorCannotUser)),
+		Entry("password policy", uint16(errorNotValidPassword)),
+		Entry("insecure transport", uint16(errorInsecureTransport)),
+	)
+
+	It("passes other errors through", func() {
+		Expect(mysqlErrorDiagnostics(&mysql.MySQLError{Number: 1064, Message: "syntax error"}, attributes)).To(Equal(diag.FromErr(&mysql.MySQLError{Number: 1064, Message: "syntax error"})))
+		Expect(mysqlErrorDiagnostics(errors.New("boom"), attributes)).To(Equal(diag.Errorf("boom")))
+	})
+})
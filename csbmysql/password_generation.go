@@ -0,0 +1,141 @@
+package csbmysql
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	upperCharacters   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lowerCharacters   = "abcdefghijklmnopqrstuvwxyz"
+	numericCharacters = "0123456789"
+	// specialCharacters leaves out characters that need escaping in connection URIs
+	specialCharacters = "!*+-.=^_~"
+
+	// errorNotValidPassword is ER_NOT_VALID_PASSWORD, returned when validate_password rejects a password
+	errorNotValidPassword = 1819
+
+	passwordGenerationAttempts = 5
+)
+
+// passwordGenerationOptions are the settings of the password_generation block
+type passwordGenerationOptions struct {
+	length  int
+	upper   bool
+	lower   bool
+	numeric bool
+	special bool
+}
+
+var defaultPasswordGeneration = passwordGenerationOptions{
+	length:  32,
+	upper:   true,
+	lower:   true,
+	numeric: true,
+	special: true,
+}
+
+// generatePassword returns a random password with at least one character of each enabled
+// class, or as many as the policy requires, and at least as long as both options and policy require
+func generatePassword(options passwordGenerationOptions, policy passwordPolicy) (string, error) {
+	classes := []struct {
+		name       string
+		enabled    bool
+		characters string
+		required   int
+	}{
+		{name: "upper", enabled: options.upper, characters: upperCharacters, required: policy.mixedCaseCount},
+		{name: "lower", enabled: options.lower, characters: lowerCharacters, required: policy.mixedCaseCount},
+		{name: "numeric", enabled: options.numeric, characters: numericCharacters, required: policy.numberCount},
+		{name: "special", enabled: options.special, characters: specialCharacters, required: policy.specialCharCount},
+	}
+
+	var (
+		password []byte
+		all      string
+	)
+	for _, class := range classes {
+		if !class.enabled {
+			if class.required > 0 {
+				return "", fmt.Errorf("the validate_password policy requires %d %s characters, but %s is false", class.required, class.name, class.name)
+			}
+			continue
+		}
+		all += class.characters
+		for range max(class.required, 1) {
+			c, err := randomCharacter(class.characters)
+			if err != nil {
+				return "", err
+			}
+			password = append(password, c)
+		}
+	}
+	if all == "" {
+		return "", errors.New("at least one of upper, lower, numeric and special must be true")
+	}
+
+	for len(password) < max(options.length, policy.length) {
+		c, err := randomCharacter(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// shuffle, so that the required characters are not always at the start
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
+
+// withGeneratedPassword calls identify with a new password until the server accepts one.
+// Even a password that meets the policy can be rejected by the STRONG policy dictionary check.
+func withGeneratedPassword(options passwordGenerationOptions, policy passwordPolicy, identify func(password string) error) (string, error) {
+	var err error
+	for attempt := 1; attempt <= passwordGenerationAttempts; attempt++ {
+		var password string
+		password, err = generatePassword(options, policy)
+		if err != nil {
+			return "", err
+		}
+
+		err = identify(password)
+		var mysqlErr *mysql.MySQLError
+		switch {
+		case err == nil:
+			return password, nil
+		case errors.As(err, &mysqlErr) && mysqlErr.Number == errorNotValidPassword:
+			log.Printf("[DEBUG] generated password rejected on attempt %d: %s", attempt, mysqlErr.Message)
+		default:
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("generated password rejected %d times: %w", passwordGenerationAttempts, err)
+}
+
+func randomCharacter(characters string) (byte, error) {
+	i, err := randomIndex(len(characters))
+	if err != nil {
+		return 0, err
+	}
+	return characters[i], nil
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("error generating password: %w", err)
+	}
+	return int(i.Int64()), nil
+}
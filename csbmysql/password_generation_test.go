@@ -0,0 +1,102 @@
+package csbmysql
+
+import (
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Password generation", func() {
+	countOf := func(password, characters string) int {
+		return len(strings.Map(func(r rune) rune {
+			if strings.ContainsRune(characters, r) {
+				return r
+			}
+			return -1
+		}, password))
+	}
+
+	Describe("generatePassword", func() {
+		It("generates a password of the requested length with each class", func() {
+			password, err := generatePassword(defaultPasswordGeneration, passwordPolicy{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).To(HaveLen(32))
+			for _, characters := range []string{upperCharacters, lowerCharacters, numericCharacters, specialCharacters} {
+				Expect(countOf(password, characters)).To(BeNumerically(">=", 1))
+			}
+		})
+
+		It("generates different passwords", func() {
+			first, err := generatePassword(defaultPasswordGeneration, passwordPolicy{})
+			Expect(err).NotTo(HaveOccurred())
+			second, err := generatePassword(defaultPasswordGeneration, passwordPolicy{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).NotTo(Equal(second))
+		})
+
+		It("leaves out disabled classes", func() {
+			password, err := generatePassword(passwordGenerationOptions{length: 16, lower: true, numeric: true}, passwordPolicy{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).To(MatchRegexp(`^[a-z0-9]{16}$`))
+		})
+
+		It("meets the validate_password policy", func() {
+			policy := passwordPolicy{length: 40, mixedCaseCount: 5, numberCount: 6, specialCharCount: 7}
+			password, err := generatePassword(passwordGenerationOptions{length: 8, upper: true, lower: true, numeric: true, special: true}, policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).To(HaveLen(40))
+			Expect(countOf(password, upperCharacters)).To(BeNumerically(">=", 5))
+			Expect(countOf(password, lowerCharacters)).To(BeNumerically(">=", 5))
+			Expect(countOf(password, numericCharacters)).To(BeNumerically(">=", 6))
+			Expect(countOf(password, specialCharacters)).To(BeNumerically(">=", 7))
+		})
+
+		It("fails when the policy requires a disabled class", func() {
+			_, err := generatePassword(passwordGenerationOptions{length: 16, upper: true, lower: true, numeric: true}, passwordPolicy{specialCharCount: 1})
+			Expect(err).To(MatchError("the validate_password policy requires 1 special characters, but special is false"))
+		})
+
+		It("fails when every class is disabled", func() {
+			_, err := generatePassword(passwordGenerationOptions{length: 16}, passwordPolicy{})
+			Expect(err).To(MatchError(ContainSubstring("at least one of")))
+		})
+	})
+
+	Describe("withGeneratedPassword", func() {
+		It("retries when the server rejects the password", func() {
+			var attempts []string
+			password, err := withGeneratedPassword(defaultPasswordGeneration, passwordPolicy{}, func(password string) error {
+				attempts = append(attempts, password)
+				if len(attempts) < 3 {
+					return &mysql.MySQLError{Number: errorNotValidPassword, Message: "Your password does not satisfy the current policy requirements"}
+				}
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(attempts).To(HaveLen(3))
+			Expect(password).To(Equal(attempts[2]))
+		})
+
+		It("gives up after a number of attempts", func() {
+			calls := 0
+			_, err := withGeneratedPassword(defaultPasswordGeneration, passwordPolicy{}, func(string) error {
+				calls++
+				return &mysql.MySQLError{Number: errorNotValidPassword}
+			})
+			Expect(err).To(MatchError(ContainSubstring("generated password rejected 5 times")))
+			Expect(calls).To(Equal(passwordGenerationAttempts))
+		})
+
+		It("does not retry other errors", func() {
+			calls := 0
+			_, err := withGeneratedPassword(defaultPasswordGeneration, passwordPolicy{}, func(string) error {
+				calls++
+				return &mysql.MySQLError{Number: 1396}
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(calls).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	passwordPolicyLow    = "LOW"
+	passwordPolicyMedium = "MEDIUM"
+	passwordPolicyStrong = "STRONG"
+)
+
+// passwordPolicy is the validate_password policy of the server.
+// The zero value is used when validate_password is not installed.
+type passwordPolicy struct {
+	installed        bool
+	level            string
+	length           int
+	mixedCaseCount   int
+	numberCount      int
+	specialCharCount int
+}
+
+// readPasswordPolicy reads the validate_password variables, which are named validate_password.*
+// for the MySQL 8 component and validate_password_* for the MySQL 5.7 plugin
+func readPasswordPolicy(ctx context.Context, db *sql.DB) (passwordPolicy, error) {
+	variables, err := queryNameValues(ctx, db, "SHOW GLOBAL VARIABLES LIKE 'validate_password%'")
+	if err != nil {
+		return passwordPolicy{}, fmt.Errorf("error reading the validate_password policy: %w", err)
+	}
+	if len(variables) == 0 {
+		return passwordPolicy{}, nil
+	}
+
+	values := make(map[string]string, len(variables))
+	for name, value := range variables {
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "validate_password."), "validate_password_")
+		values[name] = value
+	}
+
+	policy := passwordPolicy{installed: true}
+	policy.length, _ = strconv.Atoi(values["length"])
+	// LOW only checks the length, MEDIUM and STRONG also count the character classes
+	switch strings.ToUpper(values["policy"]) {
+	case passwordPolicyLow, "0":
+		policy.level = passwordPolicyLow
+	case passwordPolicyStrong, "2":
+		policy.level = passwordPolicyStrong
+	default:
+		policy.level = passwordPolicyMedium
+	}
+	if policy.level != passwordPolicyLow {
+		policy.mixedCaseCount, _ = strconv.Atoi(values["mixed_case_count"])
+		policy.numberCount, _ = strconv.Atoi(values["number_count"])
+		policy.specialCharCount, _ = strconv.Atoi(values["special_char_count"])
+	}
+	return policy, nil
+}
+
+// requiredStrength is the result of VALIDATE_PASSWORD_STRENGTH() for a password that meets the policy
+func (p passwordPolicy) requiredStrength() int {
+	switch p.level {
+	case passwordPolicyLow:
+		return 50
+	case passwordPolicyStrong:
+		return 100
+	default:
+		return 75
+	}
+}
+
+// checkPasswordPolicy returns a description of the rule a password does not meet, or an
+// empty string. VALIDATE_PASSWORD_STRENGTH() is used so that the server decides, including
+// the dictionary check of the STRONG policy that cannot be done here.
+func checkPasswordPolicy(ctx context.Context, db *sql.DB, policy passwordPolicy, password string) (string, error) {
+	if !policy.installed {
+		return "", nil
+	}
+
+	var strength int
+	if err := db.QueryRowContext(ctx, "SELECT VALIDATE_PASSWORD_STRENGTH(?)", password).Scan(&strength); err != nil {
+		return "", fmt.Errorf("error checking password strength: %w", err)
+	}
+	if strength >= policy.requiredStrength() {
+		return "", nil
+	}
+	return unmetPasswordRule(policy, password), nil
+}
+
+// unmetPasswordRule explains which rule of the policy a password rejected by the server does not meet
+func unmetPasswordRule(policy passwordPolicy, password string) string {
+	var upper, lower, number, special int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			number++
+		default:
+			special++
+		}
+	}
+
+	switch {
+	case len(password) < policy.length:
+		return fmt.Sprintf("it must have at least %d characters (validate_password.length)", policy.length)
+	case upper < policy.mixedCaseCount || lower < policy.mixedCaseCount:
+		return fmt.Sprintf("it must have at least %d upper case and %d lower case characters (validate_password.mixed_case_count)", policy.mixedCaseCount, policy.mixedCaseCount)
+	case number < policy.numberCount:
+		return fmt.Sprintf("it must have at least %d numeric characters (validate_password.number_count)", policy.numberCount)
+	case special < policy.specialCharCount:
+		return fmt.Sprintf("it must have at least %d special characters (validate_password.special_char_count)", policy.specialCharCount)
+	case policy.level == passwordPolicyStrong:
+		return "it must not contain a word of 4 or more characters from the dictionary file (validate_password.dictionary_file)"
+	default:
+		return fmt.Sprintf("it does not meet the %s policy (validate_password.policy)", policy.level)
+	}
+}
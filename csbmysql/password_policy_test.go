@@ -0,0 +1,30 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Password policy", func() {
+	medium := passwordPolicy{installed: true, level: passwordPolicyMedium, length: 8, mixedCaseCount: 1, numberCount: 1, specialCharCount: 1}
+
+	DescribeTable("requiredStrength",
+		func(level string, expected int) {
+			Expect(passwordPolicy{installed: true, level: level}.requiredStrength()).To(Equal(expected))
+		},
+		Entry("LOW", passwordPolicyLow, 50),
+		Entry("MEDIUM", passwordPolicyMedium, 75),
+		Entry("STRONG", passwordPolicyStrong, 100),
+	)
+
+	DescribeTable("unmetPasswordRule",
+		func(policy passwordPolicy, password, expected string) {
+			Expect(unmetPasswordRule(policy, password)).To(ContainSubstring(expected))
+		},
+		Entry("too short", medium, "Ab1!", "at least 8 characters (validate_password.length)"),
+		Entry("no upper case", medium, "abcdef1!", "validate_password.mixed_case_count"),
+		Entry("no number", medium, "Abcdefg!", "validate_password.number_count"),
+		Entry("no special character", medium, "Abcdefg1", "validate_password.special_char_count"),
+		Entry("dictionary word", passwordPolicy{installed: true, level: passwordPolicyStrong, length: 8}, "Password1!", "validate_password.dictionary_file"),
+	)
+})
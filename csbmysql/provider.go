@@ -8,12 +8,39 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// Descriptions shared by ProviderSchema and the schema of frameworkProvider, which must be the same
+const (
+	skipVerifyDescription             = "skip_verify controls whether a client verifies the server's certificate chain and host name. If skip_verify is true, crypto/tls accepts any certificate presented by the server and any host name in that certificate."
+	requireSecureTransportDescription = "Refuse to run any operation unless the admin session is encrypted with TLS, as reported by the server's Ssl_cipher status."
+	awsRDSIAMDescription              = "Authenticate the admin user with an AWS RDS/Aurora IAM authentication token instead of a password. Credentials are taken from the default AWS credential chain."
+	awsProfileDescription             = "Name of the AWS shared configuration profile to use."
+	gcpIAMDescription                 = "Authenticate the admin user with a GCP Cloud SQL IAM access token instead of a password."
+	gcpCredentialsDescription         = "Service account key JSON. When empty, Application Default Credentials are used."
+)
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema:               ProviderSchema(),
 		ConfigureContextFunc: ProviderConfigureContext,
 		ResourcesMap: map[string]*schema.Resource{
-			ResourceNameKey: ResourceBindingUser(),
+			ResourceNameKey:                   ResourceBindingUser(),
+			ResourceGlobalVariableNameKey:     ResourceGlobalVariable(),
+			ResourceReplicationUserNameKey:    ResourceReplicationUser(),
+			ResourceProxyGrantNameKey:         ResourceProxyGrant(),
+			ResourceRoutineNameKey:            ResourceRoutine(),
+			ResourceViewNameKey:               ResourceView(),
+			ResourceEventNameKey:              ResourceEvent(),
+			ResourceAuditLogFilterNameKey:     ResourceAuditLogFilter(),
+			ResourceAuditLogFilterUserNameKey: ResourceAuditLogFilterUser(),
+			ResourceResourceGroupNameKey:      ResourceResourceGroup(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			DataSourceServerInfoNameKey: DataSourceServerInfo(),
+			DataSourceUsersNameKey:      DataSourceUsers(),
+			DataSourceUserGrantsNameKey: DataSourceUserGrants(),
+			DataSourceDatabasesNameKey:  DataSourceDatabases(),
+			DataSourceTablesNameKey:     DataSourceTables(),
+			DataSourceVariablesNameKey:  DataSourceVariables(),
 		},
 	}
 }
@@ -34,37 +61,87 @@ func ProviderSchema() map[string]*schema.Schema {
 			Required: true,
 		},
 		passwordKey: {
-			Type:      schema.TypeString,
-			Required:  true,
-			Sensitive: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ExactlyOneOf: []string{passwordKey, awsRDSIAMKey, gcpIAMKey},
 		},
 		databaseKey: {
 			Type:     schema.TypeString,
 			Required: true,
 		},
 		sslRootCertKey: {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateCACertificates,
 		},
 		sslCertKey: {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validatePEMCertificates,
 		},
 		sslKeyKey: {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validatePEMPrivateKey,
 		},
 		skipVerifyKey: {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
-			Description: "skip_verify controls whether a client verifies the server's certificate chain and host name. If skip_verify is true, crypto/tls accepts any certificate presented by the server and any host name in that certificate.",
+			Description: skipVerifyDescription,
+		},
+		requireSecureTransportKey: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: requireSecureTransportDescription,
+		},
+		awsRDSIAMKey: {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{passwordKey, awsRDSIAMKey, gcpIAMKey},
+			Description:  awsRDSIAMDescription,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					awsRegionKey: {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					awsProfileKey: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: awsProfileDescription,
+					},
+				},
+			},
+		},
+		gcpIAMKey: {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{passwordKey, awsRDSIAMKey, gcpIAMKey},
+			Description:  gcpIAMDescription,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					gcpCredentialsKey: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: gcpCredentialsDescription,
+					},
+				},
+			},
 		},
 	}
 }
 
-func ProviderConfigureContext(_ context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func ProviderConfigureContext(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+	diags := validateProviderConfig(d)
+	if diags.HasError() {
+		return nil, diags
+	}
 
 	factory := connectionFactory{
 		host:                        d.Get(hostKey).(string),
@@ -76,7 +153,40 @@ func ProviderConfigureContext(_ context.Context, d *schema.ResourceData) (any, d
 		clientCertificate:           []byte(d.Get(sslCertKey).(string)),
 		clientCertificatePrivateKey: []byte(d.Get(sslKeyKey).(string)),
 		skipVerify:                  d.Get(skipVerifyKey).(bool),
+		requireSecureTransport:      d.Get(requireSecureTransportKey).(bool),
+		grantsCache:                 &adminGrantsCache{},
+	}
+
+	diags = append(diags, serverIdentityWarning(factory)...)
+
+	generator, err := authTokenGeneratorFromConfig(ctx, d)
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+	if generator != nil {
+		factory.authTokens = newRefreshingTokenSource(generator, connMaxLifetime)
 	}
 
+	factory.dialect = newServerDialect(factory.detectServer)
+
 	return factory, diags
 }
+
+func authTokenGeneratorFromConfig(ctx context.Context, d *schema.ResourceData) (authTokenGenerator, error) {
+	if len(d.Get(awsRDSIAMKey).([]any)) > 0 {
+		return newRDSTokenGenerator(
+			ctx,
+			d.Get(hostKey).(string),
+			d.Get(portKey).(int),
+			d.Get(awsRDSIAMKey+".0."+awsRegionKey).(string),
+			d.Get(awsRDSIAMKey+".0."+awsProfileKey).(string),
+			d.Get(usernameKey).(string),
+		)
+	}
+
+	if len(d.Get(gcpIAMKey).([]any)) > 0 {
+		return newGCPTokenGenerator(ctx, d.Get(gcpIAMKey+".0."+gcpCredentialsKey).(string))
+	}
+
+	return nil, nil
+}
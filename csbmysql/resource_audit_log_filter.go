@@ -0,0 +1,153 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	auditLogFilterNameKey       = "name"
+	auditLogFilterDefinitionKey = "definition"
+)
+
+func ResourceAuditLogFilter() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceAuditLogFilterSchema,
+		CreateContext: resourceAuditLogFilterCreate,
+		ReadContext:   resourceAuditLogFilterRead,
+		DeleteContext: resourceAuditLogFilterDelete,
+		CustomizeDiff: resourceAuditLogFilterCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "An audit log filter, defined with audit_log_filter_set_filter. Requires the MySQL Enterprise " +
+			"audit_log plugin or Percona Server audit_log_filter. Replacing a filter unassigns the users it was assigned to.",
+	}
+}
+
+var resourceAuditLogFilterSchema = map[string]*schema.Schema{
+	auditLogFilterNameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	auditLogFilterDefinitionKey: {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		Description:      "JSON filter definition, for example {\"filter\": {\"class\": {\"name\": \"connection\"}}}.",
+	},
+}
+
+func resourceAuditLogFilterCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceAuditLogFilterCreate()")
+	defer log.Println("[DEBUG] EXIT resourceAuditLogFilterCreate()")
+
+	name := d.Get(auditLogFilterNameKey).(string)
+	definition := d.Get(auditLogFilterDefinitionKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := requireAuditLogFilter(ctx, db, cf.dialect); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := callAuditLogFunction(ctx, db, "audit_log_filter_set_filter", name, definition); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+
+	return resourceAuditLogFilterRead(ctx, d, m)
+}
+
+func resourceAuditLogFilterRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceAuditLogFilterRead()")
+	defer log.Println("[DEBUG] EXIT resourceAuditLogFilterRead()")
+
+	name := d.Id()
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := requireAuditLogFilter(ctx, db, cf.dialect); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var definition string
+	err = db.QueryRowContext(ctx, "SELECT FILTER FROM mysql.audit_log_filter WHERE NAME = ?", name).Scan(&definition)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] audit log filter %s no longer exists", name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading audit log filter %q: %w", name, err))
+	}
+
+	if err := d.Set(auditLogFilterNameKey, name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(auditLogFilterDefinitionKey, definition); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAuditLogFilterDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceAuditLogFilterDelete()")
+	defer log.Println("[DEBUG] EXIT resourceAuditLogFilterDelete()")
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := callAuditLogFunction(ctx, db, "audit_log_filter_remove_filter", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceAuditLogFilterCustomizeDiff checks that the admin user can define audit log filters
+func resourceAuditLogFilterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok || d.Id() != "" {
+		return nil
+	}
+
+	action := fmt.Sprintf("set audit log filter %q", d.Get(auditLogFilterNameKey).(string))
+	return checkAdminPrivilegesAtPlan(ctx, cf, action, []requiredPrivilege{auditAdminPrivilege})
+}
@@ -0,0 +1,95 @@
+package csbmysql_test
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+// The community MySQL server used in the tests has no audit log plugin, so these
+// tests check that the resources fail cleanly rather than on an unknown function
+var _ = Describe("Audit log filter resources", func() {
+	unavailable := regexp.MustCompile("audit log filtering unavailable")
+
+	It("reports that the audit log plugin is not installed", func() {
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{
+				{
+					Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "connections" {
+  name       = "log_connections"
+  definition = jsonencode({ filter = { class = { name = "connection" } } })
+}
+`, csbmysql.ResourceAuditLogFilterNameKey)),
+					ExpectError: unavailable,
+				},
+				{
+					Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "default" {
+  username = "%%"
+  filter   = "log_connections"
+}
+`, csbmysql.ResourceAuditLogFilterUserNameKey)),
+					ExpectError: unavailable,
+				},
+			},
+		})
+	})
+
+	It("does not create a binding user with an audit filter", func() {
+		const username = "audited-user"
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "audited" {
+  username     = "%s"
+  password     = "audited-password"
+  read_only    = true
+  audit_filter = "log_connections"
+}
+`, csbmysql.ResourceNameKey, username)),
+					ExpectError: unavailable,
+				},
+			},
+		})
+	})
+
+	It("does not add an audit filter to a binding user", func() {
+		const username = "later-audited-user"
+		bindingUserDefinition := func(auditFilter string) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "audited" {
+  username     = "%s"
+  password     = "audited-password"
+  read_only    = true
+  audit_filter = "%s"
+}
+`, csbmysql.ResourceNameKey, username, auditFilter))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: bindingUserDefinition(""),
+				},
+				{
+					Config:      bindingUserDefinition("log_connections"),
+					ExpectError: unavailable,
+				},
+			},
+		})
+	})
+})
@@ -0,0 +1,180 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	auditLogFilterUserUsernameKey = "username"
+	auditLogFilterUserHostKey     = "host"
+	auditLogFilterUserFilterKey   = "filter"
+)
+
+func ResourceAuditLogFilterUser() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceAuditLogFilterUserSchema,
+		CreateContext: resourceAuditLogFilterUserCreate,
+		ReadContext:   resourceAuditLogFilterUserRead,
+		UpdateContext: resourceAuditLogFilterUserUpdate,
+		DeleteContext: resourceAuditLogFilterUserDelete,
+		CustomizeDiff: resourceAuditLogFilterUserCustomizeDiff,
+		Description:   "Assigns an audit log filter to an account with audit_log_filter_set_user",
+	}
+}
+
+var resourceAuditLogFilterUserSchema = map[string]*schema.Schema{
+	auditLogFilterUserUsernameKey: {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "User to assign the filter to, or \"%\" for the default filter of accounts without one.",
+	},
+	auditLogFilterUserHostKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     bindingUserHostAll,
+		Description: "Host of the account. Ignored for the default account \"%\".",
+	},
+	auditLogFilterUserFilterKey: {
+		Type:     schema.TypeString,
+		Required: true,
+	},
+}
+
+func resourceAuditLogFilterUserCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceAuditLogFilterUserCreate()")
+	defer log.Println("[DEBUG] EXIT resourceAuditLogFilterUserCreate()")
+
+	if diags := setAuditLogFilterUser(ctx, d, m); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(auditLogFilterUserAccount(d))
+
+	return resourceAuditLogFilterUserRead(ctx, d, m)
+}
+
+func resourceAuditLogFilterUserRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceAuditLogFilterUserRead()")
+	defer log.Println("[DEBUG] EXIT resourceAuditLogFilterUserRead()")
+
+	username := d.Get(auditLogFilterUserUsernameKey).(string)
+	host := d.Get(auditLogFilterUserHostKey).(string)
+	if username == "%" {
+		host = ""
+	}
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := requireAuditLogFilter(ctx, db, cf.dialect); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var filter string
+	err = db.QueryRowContext(ctx, "SELECT FILTERNAME FROM mysql.audit_log_user WHERE USER = ? AND HOST = ?", username, host).Scan(&filter)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] audit log filter is no longer assigned to %s", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading audit log filter of %q: %w", d.Id(), err))
+	}
+
+	if err := d.Set(auditLogFilterUserFilterKey, filter); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAuditLogFilterUserUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceAuditLogFilterUserUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceAuditLogFilterUserUpdate()")
+
+	if diags := setAuditLogFilterUser(ctx, d, m); diags.HasError() {
+		return diags
+	}
+
+	return resourceAuditLogFilterUserRead(ctx, d, m)
+}
+
+func resourceAuditLogFilterUserDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceAuditLogFilterUserDelete()")
+	defer log.Println("[DEBUG] EXIT resourceAuditLogFilterUserDelete()")
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := callAuditLogFunction(ctx, db, "audit_log_filter_remove_user", auditLogFilterUserAccount(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func setAuditLogFilterUser(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if err := requireAuditLogFilter(ctx, db, cf.dialect); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := callAuditLogFunction(ctx, db, "audit_log_filter_set_user", auditLogFilterUserAccount(d), d.Get(auditLogFilterUserFilterKey).(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// auditLogFilterUserAccount returns the account the filter is assigned to. The default
+// account is just %, and matches any account without a filter of its own.
+func auditLogFilterUserAccount(d *schema.ResourceData) string {
+	username := d.Get(auditLogFilterUserUsernameKey).(string)
+	if username == "%" {
+		return username
+	}
+	return auditLogAccount(username, d.Get(auditLogFilterUserHostKey).(string))
+}
+
+// resourceAuditLogFilterUserCustomizeDiff checks that the admin user can assign audit log filters
+func resourceAuditLogFilterUserCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok || (d.Id() != "" && !d.HasChange(auditLogFilterUserFilterKey)) {
+		return nil
+	}
+
+	action := fmt.Sprintf("assign audit log filter %q", d.Get(auditLogFilterUserFilterKey).(string))
+	return checkAdminPrivilegesAtPlan(ctx, cf, action, []requiredPrivilege{auditAdminPrivilege})
+}
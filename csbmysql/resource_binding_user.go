@@ -3,20 +3,49 @@ package csbmysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	bindingUsernameKey = "username"
-	bindingPasswordKey = "password"
-	bindingInsecureKey = "allow_insecure_connections"
-	bindingUserHostAll = "%"
-	bindingReadOnlyKey = "read_only"
+	bindingUsernameKey            = "username"
+	bindingPasswordKey            = "password"
+	bindingInsecureKey            = "allow_insecure_connections"
+	bindingUserHostAll            = "%"
+	bindingReadOnlyKey            = "read_only"
+	bindingIAMKey                 = "iam_authentication"
+	bindingViewsOnlyKey           = "views_only"
+	bindingAuditFilterKey         = "audit_filter"
+	bindingResourceGroupKey       = "resource_group"
+	bindingInitCommandKey         = "init_command"
+	bindingAccountLockedKey       = "account_locked"
+	bindingKillSessionsKey        = "kill_sessions_on_lock"
+	bindingRetainPasswordKey      = "retain_current_password"
+	bindingDiscardOldPasswordKey  = "discard_old_password"
+	bindingOldPasswordRetainedKey = "old_password_retained"
+	bindingPasswordWOKey          = "password_wo"
+	bindingPasswordWOVersionKey   = "password_wo_version"
+	bindingPasswordGenerationKey  = "password_generation"
+	bindingGeneratedPasswordKey   = "generated_password"
+	passwordGenerationLengthKey   = "length"
+	passwordGenerationUpperKey    = "upper"
+	passwordGenerationLowerKey    = "lower"
+	passwordGenerationNumericKey  = "numeric"
+	passwordGenerationSpecialKey  = "special"
+
+	// errorUnknownThread is ER_NO_SUCH_THREAD, returned when killing a session that has already ended
+	errorUnknownThread = 1094
+
+	iamAuthenticationAWSRDS      = "aws_rds"
+	iamAuthenticationGCPCloudSQL = "gcp_cloudsql"
 )
 
 var (
@@ -31,6 +60,7 @@ func ResourceBindingUser() *schema.Resource {
 		ReadContext:   resourceBindingUserRead,
 		UpdateContext: resourceBindingUserUpdate,
 		DeleteContext: resourceBindingUserDelete,
+		CustomizeDiff: resourceBindingUserCustomizeDiff,
 		Description:   "A MySQL Server binding for the CSB brokerpak",
 		UseJSONNumber: true,
 	}
@@ -42,9 +72,80 @@ var resourceBindingUserSchema = map[string]*schema.Schema{
 		Required: true,
 	},
 	bindingPasswordKey: {
-		Type:      schema.TypeString,
-		Required:  true,
-		Sensitive: true,
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		ExactlyOneOf: []string{bindingPasswordKey, bindingPasswordWOKey, bindingPasswordGenerationKey, bindingIAMKey},
+	},
+	bindingPasswordWOKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		WriteOnly:    true,
+		ExactlyOneOf: []string{bindingPasswordKey, bindingPasswordWOKey, bindingPasswordGenerationKey, bindingIAMKey},
+		RequiredWith: []string{bindingPasswordWOVersionKey},
+		Description: "Password of the user that is never stored in the plan or state. Requires Terraform 1.11. " +
+			"As the provider cannot tell when it changes, change password_wo_version to set a new password.",
+	},
+	bindingPasswordWOVersionKey: {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{bindingPasswordWOKey},
+		Description:  "Any value that changes whenever password_wo changes, such as a counter.",
+	},
+	bindingPasswordGenerationKey: {
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: []string{bindingPasswordKey, bindingPasswordWOKey, bindingPasswordGenerationKey, bindingIAMKey},
+		Description: "Generate a random password for the user, exposed as generated_password. The password is made long enough " +
+			"and with enough characters of each class to meet the validate_password policy of the server. Changing these settings generates a new password.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				passwordGenerationLengthKey: {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultPasswordGeneration.length,
+					ValidateFunc: validation.IntAtLeast(8),
+					Description:  "Length of the password, raised to validate_password.length if that is longer.",
+				},
+				passwordGenerationUpperKey: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  defaultPasswordGeneration.upper,
+				},
+				passwordGenerationLowerKey: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  defaultPasswordGeneration.lower,
+				},
+				passwordGenerationNumericKey: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  defaultPasswordGeneration.numeric,
+				},
+				passwordGenerationSpecialKey: {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     defaultPasswordGeneration.special,
+					Description: "Include characters from " + specialCharacters + ", which need no escaping in connection URIs.",
+				},
+			},
+		},
+	},
+	bindingGeneratedPasswordKey: {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "Password generated when password_generation is set.",
+	},
+	bindingIAMKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ExactlyOneOf: []string{bindingPasswordKey, bindingPasswordWOKey, bindingPasswordGenerationKey, bindingIAMKey},
+		ValidateFunc: validation.StringInSlice([]string{iamAuthenticationAWSRDS, iamAuthenticationGCPCloudSQL}, false),
+		Description:  "Create a user that logs in with cloud IAM instead of a password. One of \"aws_rds\" or \"gcp_cloudsql\".",
 	},
 	bindingInsecureKey: {
 		Type:     schema.TypeBool,
@@ -55,6 +156,61 @@ var resourceBindingUserSchema = map[string]*schema.Schema{
 		Optional: true,
 		Default:  false,
 	},
+	bindingViewsOnlyKey: {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		ForceNew: true,
+		Description: "Do not grant SELECT on the whole database, so that the user can only read views it is " +
+			"granted with the select_grantees of csbmysql_view. Requires read_only.",
+	},
+	bindingAuditFilterKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Name of an audit log filter, such as one defined with csbmysql_audit_log_filter, to assign to the user.",
+	},
+	bindingResourceGroupKey: {
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Name of a USER resource group, such as one defined with csbmysql_resource_group, for the sessions of the user. " +
+			"MySQL has no per account resource group, so the user is allowed to join it and init_command must be run by the application on connecting.",
+	},
+	bindingInitCommandKey: {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Statement for the application to run on each new connection, such as SET RESOURCE GROUP, if any.",
+	},
+	bindingAccountLockedKey: {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Lock the account, for example while the app is suspended. Locking does not end existing sessions unless kill_sessions_on_lock is set.",
+	},
+	bindingKillSessionsKey: {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Kill the sessions of the user when the account is locked.",
+	},
+	bindingRetainPasswordKey: {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Rotate the password with RETAIN CURRENT PASSWORD, so that the previous password keeps working " +
+			"until this is set back to false or discard_old_password is set, for example once apps have restaged. Requires MySQL 8.0.14.",
+	},
+	bindingDiscardOldPasswordKey: {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Discard the password retained by the last rotation when this changes to true. " +
+			"The retained password is also discarded when retain_current_password changes to false, which can be repeated for each rotation.",
+	},
+	bindingOldPasswordRetainedKey: {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether a previous password retained by a rotation still works.",
+	},
 }
 
 func resourceBindingUserCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -65,23 +221,47 @@ func resourceBindingUserCreate(ctx context.Context, d *schema.ResourceData, m an
 	defer log.Println("[DEBUG] EXIT resourceBindingUserCreate()")
 
 	username := d.Get(bindingUsernameKey).(string)
-	password := d.Get(bindingPasswordKey).(string)
+	password, diags := bindingPassword(d)
+	if diags.HasError() {
+		return diags
+	}
 	allowInsecureConnections := d.Get(bindingInsecureKey).(bool)
 	readOnly := d.Get(bindingReadOnlyKey).(bool)
+	iamAuthentication := d.Get(bindingIAMKey).(string)
+	viewsOnly := d.Get(bindingViewsOnlyKey).(bool)
+	auditFilter := d.Get(bindingAuditFilterKey).(string)
+	resourceGroup := d.Get(bindingResourceGroupKey).(string)
+	accountLocked := d.Get(bindingAccountLockedKey).(bool)
 
 	cf := m.(connectionFactory)
+	if resourceGroup != "" {
+		if err := requireFeature(cf.dialect, featureResourceGroups); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+	if accountLocked {
+		if err := requireFeature(cf.dialect, featureAccountLocking); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
 
 	db, err := cf.ConnectAsAdmin()
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
 	}
 	defer func(db *sql.DB) {
 		_ = db.Close()
 	}(db)
 
+	if auditFilter != "" {
+		if err := requireAuditLogFilter(ctx, db, cf.dialect); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
 	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
 	}
 	defer func(tx *sql.Tx) {
 		_ = tx.Rollback()
@@ -92,24 +272,35 @@ func resourceBindingUserCreate(ctx context.Context, d *schema.ResourceData, m an
 	log.Println("[DEBUG] create binding user")
 	userPresent, err := userExists(db, username, "%")
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
 	}
 
+	generatedPassword := ""
 	if !userPresent {
-		sslRequirement := "REQUIRE SSL"
-		if allowInsecureConnections {
-			sslRequirement = ""
-		}
-		_, err := tx.Exec(
-			fmt.Sprintf("CREATE USER %s@%s IDENTIFIED BY %s %s",
-				quotedIdentifier(username),
-				quotedIdentifier(bindingUserHostAll),
-				quotedString(password),
-				sslRequirement,
-			),
-		)
-		if err != nil {
-			return diag.FromErr(err)
+		createUser := func(identification string) error {
+			_, err := tx.Exec(cf.dialect.createUserStatement(username, bindingUserHostAll, identification, tlsRequirement{ssl: !allowInsecureConnections}))
+			return err
+		}
+
+		if options, ok := passwordGenerationFromConfig(d); ok {
+			policy, err := readPasswordPolicy(ctx, db)
+			if err != nil {
+				return bindingUserDiagnostics(d, err)
+			}
+			generatedPassword, err = withGeneratedPassword(options, policy, func(password string) error {
+				return createUser(fmt.Sprintf("IDENTIFIED BY %s", quotedString(password)))
+			})
+			if err != nil {
+				return bindingUserDiagnostics(d, err)
+			}
+		} else {
+			identification, err := identifiedClause(cf.dialect, password, iamAuthentication)
+			if err != nil {
+				return bindingUserDiagnostics(d, err)
+			}
+			if err := createUser(identification); err != nil {
+				return bindingUserDiagnostics(d, err)
+			}
 		}
 	}
 
@@ -118,28 +309,57 @@ func resourceBindingUserCreate(ctx context.Context, d *schema.ResourceData, m an
 		permission = "SELECT"
 	}
 
-	grantStatement := fmt.Sprintf("GRANT %s ON %s.* TO %s@%s",
-		permission,
-		quotedIdentifier(cf.database),
-		quotedIdentifier(username),
-		quotedIdentifier(bindingUserHostAll))
-	_, err = tx.Exec(grantStatement)
-	if err != nil {
-		return diag.FromErr(err)
+	if !viewsOnly {
+		_, err = tx.Exec(cf.dialect.grantStatement(permission, cf.database, username, bindingUserHostAll))
+		if err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	if resourceGroup != "" {
+		// RESOURCE_GROUP_USER lets the user assign its own sessions to USER resource groups
+		_, err = tx.Exec(cf.dialect.grantGlobalStatement("RESOURCE_GROUP_USER", username, bindingUserHostAll))
+		if err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	if accountLocked {
+		_, err = tx.Exec(cf.dialect.alterUserStatement(username, bindingUserHostAll, "ACCOUNT LOCK"))
+		if err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
+	}
+
+	if err := d.Set(bindingInitCommandKey, resourceGroupInitCommand(resourceGroup)); err != nil {
+		return bindingUserDiagnostics(d, err)
+	}
+	if err := d.Set(bindingGeneratedPasswordKey, generatedPassword); err != nil {
+		return bindingUserDiagnostics(d, err)
 	}
 
 	log.Printf("[DEBUG] setting ID %s\n", username)
 	d.SetId(username)
 
+	// The audit log functions are not transactional, so the filter is assigned once the user exists.
+	// Should that fail, the user is kept in the state without the filter, so that it is not left behind.
+	if auditFilter != "" {
+		log.Println("[DEBUG] assigning audit log filter")
+		if err := callAuditLogFunction(ctx, db, "audit_log_filter_set_user", auditLogAccount(username, bindingUserHostAll), auditFilter); err != nil {
+			_ = d.Set(bindingAuditFilterKey, "")
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
 	return nil
 }
 
-func resourceBindingUserRead(_ context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+func resourceBindingUserRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	log.Println("[DEBUG] ENTRY resourceBindingUserRead()")
 	defer log.Println("[DEBUG] EXIT resourceBindingUserRead()")
 
@@ -149,7 +369,7 @@ func resourceBindingUserRead(_ context.Context, d *schema.ResourceData, m any) d
 
 	db, err := cf.ConnectAsAdmin()
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
 	}
 	defer func(db *sql.DB) {
 		_ = db.Close()
@@ -157,17 +377,326 @@ func resourceBindingUserRead(_ context.Context, d *schema.ResourceData, m any) d
 
 	userPresent, err := userExists(db, username, bindingUserHostAll)
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
+	}
+	if !userPresent {
+		return nil
+	}
+	d.SetId(username)
+
+	// MySQL has no per account resource group, so the statement only depends on the configuration
+	if err := d.Set(bindingInitCommandKey, resourceGroupInitCommand(d.Get(bindingResourceGroupKey).(string))); err != nil {
+		return bindingUserDiagnostics(d, err)
 	}
-	if userPresent {
-		d.SetId(username)
+
+	if cf.dialect.supports(featureAccountLocking) {
+		var locked bool
+		if err := db.QueryRowContext(ctx, accountLockedQuery(cf.dialect), username, bindingUserHostAll).Scan(&locked); err != nil {
+			return bindingUserDiagnostics(d, fmt.Errorf("error reading whether %q is locked: %w", username, err))
+		}
+		if err := d.Set(bindingAccountLockedKey, locked); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	if cf.dialect.supports(featureDualPasswords) {
+		var retained bool
+		err := db.QueryRowContext(ctx,
+			"SELECT COALESCE(JSON_CONTAINS_PATH(User_attributes, 'one', '$.additional_password'), FALSE) FROM mysql.user WHERE User = ? AND Host = ?",
+			username, bindingUserHostAll,
+		).Scan(&retained)
+		if err != nil {
+			return bindingUserDiagnostics(d, fmt.Errorf("error reading whether %q has a retained password: %w", username, err))
+		}
+		if err := d.Set(bindingOldPasswordRetainedKey, retained); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
 	}
 
 	return nil
 }
 
-func resourceBindingUserUpdate(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
-	return diag.FromErr(fmt.Errorf("update lifecycle not implemented"))
+// resourceBindingUserCustomizeDiff shows that a new password will be generated when the settings change and the new init_command,
+// and checks the settings and a new password against the validate_password policy so that they fail the plan rather than the apply
+func resourceBindingUserCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if d.Get(bindingViewsOnlyKey).(bool) && d.NewValueKnown(bindingReadOnlyKey) && !d.Get(bindingReadOnlyKey).(bool) {
+		return cty.GetAttrPath(bindingViewsOnlyKey).NewErrorf("%s requires %s", bindingViewsOnlyKey, bindingReadOnlyKey)
+	}
+
+	if d.Id() != "" && d.HasChange(bindingPasswordGenerationKey) {
+		if err := d.SetNewComputed(bindingGeneratedPasswordKey); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && d.HasChange(bindingResourceGroupKey) {
+		var err error
+		if d.NewValueKnown(bindingResourceGroupKey) {
+			err = d.SetNew(bindingInitCommandKey, resourceGroupInitCommand(d.Get(bindingResourceGroupKey).(string)))
+		} else {
+			err = d.SetNewComputed(bindingInitCommandKey)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := checkBindingUserPrivileges(ctx, d, m); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && !d.HasChanges(bindingPasswordKey, bindingPasswordWOVersionKey) {
+		return nil
+	}
+	return validateBindingPassword(ctx, d, m)
+}
+
+// checkBindingUserPrivileges checks that the admin user can create the binding user and give it its grants,
+// or make the planned changes to it
+func checkBindingUserPrivileges(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok {
+		return nil
+	}
+	username := d.Get(bindingUsernameKey).(string)
+
+	if d.Id() == "" {
+		required := []requiredPrivilege{{privilege: "CREATE USER"}}
+		if !d.Get(bindingViewsOnlyKey).(bool) {
+			privileges := databasePrivileges
+			if d.Get(bindingReadOnlyKey).(bool) {
+				privileges = []string{"SELECT"}
+			}
+			for _, privilege := range privileges {
+				required = append(required, requiredPrivilege{privilege: privilege, database: cf.database, grantOption: true})
+			}
+		}
+		if d.Get(bindingResourceGroupKey).(string) != "" {
+			required = append(required, requiredPrivilege{privilege: "RESOURCE_GROUP_USER", grantOption: true})
+		}
+		if d.Get(bindingAuditFilterKey).(string) != "" {
+			required = append(required, auditAdminPrivilege)
+		}
+		return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("create binding user %q", username), required)
+	}
+
+	var required []requiredPrivilege
+	// ALTER USER on another account needs CREATE USER
+	if d.HasChanges(bindingPasswordKey, bindingPasswordWOVersionKey, bindingPasswordGenerationKey, bindingRetainPasswordKey, bindingDiscardOldPasswordKey, bindingAccountLockedKey) {
+		required = append(required, requiredPrivilege{privilege: "CREATE USER"})
+	}
+	if d.HasChange(bindingAccountLockedKey) && d.Get(bindingAccountLockedKey).(bool) && d.Get(bindingKillSessionsKey).(bool) {
+		// KILL of the sessions of another user, CONNECTION ADMIN is the MariaDB name
+		required = append(required, requiredPrivilege{privilege: "CONNECTION_ADMIN", alternatives: []requiredPrivilege{{privilege: "CONNECTION ADMIN"}, {privilege: "SUPER"}}})
+	}
+	if oldGroup, newGroup := d.GetChange(bindingResourceGroupKey); d.HasChange(bindingResourceGroupKey) && (oldGroup == "" || newGroup == "") {
+		required = append(required, requiredPrivilege{privilege: "RESOURCE_GROUP_USER", grantOption: true})
+	}
+	if d.HasChange(bindingAuditFilterKey) {
+		required = append(required, auditAdminPrivilege)
+	}
+	return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("update binding user %q", username), required)
+}
+
+// validateBindingPassword is skipped when the password is not known yet or the server cannot be
+// reached, for example because it is created in the same apply. The apply then reports the error.
+func validateBindingPassword(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	attribute := bindingPasswordKey
+	if !d.NewValueKnown(bindingPasswordKey) {
+		return nil
+	}
+	password, diags := bindingPassword(d)
+	if diags.HasError() || password == "" {
+		return nil
+	}
+	if d.Get(bindingPasswordKey).(string) == "" {
+		attribute = bindingPasswordWOKey
+	}
+
+	cf, ok := m.(connectionFactory)
+	if !ok {
+		return nil
+	}
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		log.Printf("[DEBUG] skipping password policy check: %s", err)
+		return nil
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	policy, err := readPasswordPolicy(ctx, db)
+	if err != nil {
+		log.Printf("[DEBUG] skipping password policy check: %s", err)
+		return nil
+	}
+	rule, err := checkPasswordPolicy(ctx, db, policy, password)
+	if err != nil {
+		log.Printf("[DEBUG] skipping password policy check: %s", err)
+		return nil
+	}
+	if rule != "" {
+		return cty.GetAttrPath(attribute).NewErrorf("password does not satisfy the validate_password policy of the server: %s", rule)
+	}
+	return nil
+}
+
+// resourceBindingUserUpdate changes the password, the audit filter and the resource group, and locks
+// or unlocks the account, other changes are not implemented
+func resourceBindingUserUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceBindingUserUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceBindingUserUpdate()")
+
+	if d.HasChangesExcept(bindingPasswordKey, bindingPasswordWOVersionKey, bindingPasswordGenerationKey, bindingRetainPasswordKey, bindingDiscardOldPasswordKey, bindingAccountLockedKey, bindingKillSessionsKey, bindingAuditFilterKey, bindingResourceGroupKey) {
+		return diag.FromErr(fmt.Errorf("update lifecycle not implemented"))
+	}
+
+	username := d.Get(bindingUsernameKey).(string)
+	password, diags := bindingPassword(d)
+	if diags.HasError() {
+		return diags
+	}
+	// password_wo is not in the state, so a new one is only noticed through its version
+	passwordChanged := d.HasChanges(bindingPasswordKey, bindingPasswordWOVersionKey, bindingPasswordGenerationKey)
+	retainPassword := d.Get(bindingRetainPasswordKey).(bool)
+	// Ending a rotation by no longer retaining passwords discards the one still retained
+	oldRetainPassword, _ := d.GetChange(bindingRetainPasswordKey)
+	discardOldPassword := (d.Get(bindingDiscardOldPasswordKey).(bool) && d.HasChange(bindingDiscardOldPasswordKey)) ||
+		(oldRetainPassword.(bool) && !retainPassword && d.Get(bindingOldPasswordRetainedKey).(bool))
+	accountLocked := d.Get(bindingAccountLockedKey).(bool)
+
+	cf := m.(connectionFactory)
+	if (retainPassword && passwordChanged) || discardOldPassword {
+		if err := requireFeature(cf.dialect, featureDualPasswords); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+	if d.HasChange(bindingAccountLockedKey) {
+		if err := requireFeature(cf.dialect, featureAccountLocking); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+	if d.HasChange(bindingResourceGroupKey) && d.Get(bindingResourceGroupKey).(string) != "" {
+		if err := requireFeature(cf.dialect, featureResourceGroups); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return bindingUserDiagnostics(d, err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if d.HasChange(bindingAuditFilterKey) {
+		if err := updateAuditFilter(ctx, db, cf.dialect, username, d.Get(bindingAuditFilterKey).(string)); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	if d.HasChange(bindingResourceGroupKey) {
+		oldGroup, newGroup := d.GetChange(bindingResourceGroupKey)
+		if err := updateResourceGroupUser(ctx, db, cf.dialect, username, oldGroup.(string), newGroup.(string)); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	if passwordChanged {
+		identify := func(password string) error {
+			clause := fmt.Sprintf("IDENTIFIED BY %s", quotedString(password))
+			if retainPassword {
+				// The previous password keeps working until it is discarded, so running apps are not locked out
+				clause += " RETAIN CURRENT PASSWORD"
+			}
+			_, err := db.ExecContext(ctx, cf.dialect.alterUserStatement(username, bindingUserHostAll, clause))
+			return err
+		}
+
+		log.Println("[DEBUG] changing password")
+		generatedPassword := ""
+		if options, ok := passwordGenerationFromConfig(d); ok {
+			policy, err := readPasswordPolicy(ctx, db)
+			if err != nil {
+				return bindingUserDiagnostics(d, err)
+			}
+			if generatedPassword, err = withGeneratedPassword(options, policy, identify); err != nil {
+				return bindingUserDiagnostics(d, err)
+			}
+		} else if err := identify(password); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+		if err := d.Set(bindingGeneratedPasswordKey, generatedPassword); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	if discardOldPassword {
+		log.Println("[DEBUG] discarding old password")
+		if _, err := db.ExecContext(ctx, cf.dialect.alterUserStatement(username, bindingUserHostAll, "DISCARD OLD PASSWORD")); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
+	if d.HasChange(bindingAccountLockedKey) {
+		clause := "ACCOUNT UNLOCK"
+		if accountLocked {
+			clause = "ACCOUNT LOCK"
+		}
+		log.Printf("[DEBUG] %s", clause)
+		if _, err := db.ExecContext(ctx, cf.dialect.alterUserStatement(username, bindingUserHostAll, clause)); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+
+		if accountLocked && d.Get(bindingKillSessionsKey).(bool) {
+			if err := killSessions(ctx, db, username); err != nil {
+				return bindingUserDiagnostics(d, err)
+			}
+		}
+	}
+
+	return resourceBindingUserRead(ctx, d, m)
+}
+
+// updateAuditFilter assigns the filter to the user, replacing the one it had, or removes the assignment when it is empty
+func updateAuditFilter(ctx context.Context, db *sql.DB, dialect dialect, username, auditFilter string) error {
+	if err := requireAuditLogFilter(ctx, db, dialect); err != nil {
+		return err
+	}
+
+	account := auditLogAccount(username, bindingUserHostAll)
+	if auditFilter == "" {
+		log.Println("[DEBUG] unassigning audit log filter")
+		return callAuditLogFunction(ctx, db, "audit_log_filter_remove_user", account)
+	}
+	log.Println("[DEBUG] assigning audit log filter")
+	return callAuditLogFunction(ctx, db, "audit_log_filter_set_user", account, auditFilter)
+}
+
+// updateResourceGroupUser grants RESOURCE_GROUP_USER when the user gets a resource group and revokes it when
+// the user no longer has one. Moving to another group needs no change, as the privilege is for all USER groups.
+func updateResourceGroupUser(ctx context.Context, db *sql.DB, dialect dialect, username, oldGroup, newGroup string) error {
+	switch {
+	case oldGroup == "" && newGroup != "":
+		log.Println("[DEBUG] granting RESOURCE_GROUP_USER")
+		_, err := db.ExecContext(ctx, dialect.grantGlobalStatement("RESOURCE_GROUP_USER", username, bindingUserHostAll))
+		return err
+	case oldGroup != "" && newGroup == "":
+		log.Println("[DEBUG] revoking RESOURCE_GROUP_USER")
+		_, err := db.ExecContext(ctx, dialect.revokeGlobalStatement("RESOURCE_GROUP_USER", username, bindingUserHostAll))
+		return err
+	default:
+		return nil
+	}
+}
+
+// resourceGroupInitCommand is the statement the application runs to join the resource group, if any
+func resourceGroupInitCommand(resourceGroup string) string {
+	if resourceGroup == "" {
+		return ""
+	}
+	return fmt.Sprintf("SET RESOURCE GROUP %s", quotedIdentifier(resourceGroup))
 }
 
 func resourceBindingUserDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -178,39 +707,149 @@ func resourceBindingUserDelete(ctx context.Context, d *schema.ResourceData, m an
 	defer deleteBindingMutex.Unlock()
 
 	bindingUser := d.Get(bindingUsernameKey).(string)
+	auditFilter := d.Get(bindingAuditFilterKey).(string)
 
 	cf := m.(connectionFactory)
 
 	db, err := cf.ConnectAsAdmin()
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
 	}
 
 	defer func(connection *sql.DB) {
 		_ = connection.Close()
 	}(db)
 
+	// Destroying does not plan with CustomizeDiff, so DROP USER is checked before the audit filter is removed
+	if err := checkAdminPrivileges(ctx, cf, db, []requiredPrivilege{dropUserPrivilege}); err != nil {
+		return bindingUserDiagnostics(d, fmt.Errorf("unable to delete binding user %q: %w", bindingUser, err))
+	}
+
+	if auditFilter != "" {
+		log.Println("[DEBUG] unassigning audit log filter")
+		if err := callAuditLogFunction(ctx, db, "audit_log_filter_remove_user", auditLogAccount(bindingUser, bindingUserHostAll)); err != nil {
+			return bindingUserDiagnostics(d, err)
+		}
+	}
+
 	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
 	}
 	defer func(transaction *sql.Tx) {
 		_ = transaction.Rollback()
 	}(tx)
 
 	log.Println("[DEBUG] dropping binding user")
-	_, err = tx.Exec(fmt.Sprintf("DROP USER '%s'@'%s'", bindingUser, bindingUserHostAll))
+	_, err = tx.Exec(cf.dialect.dropUserStatement(bindingUser, bindingUserHostAll))
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return diag.FromErr(err)
+		return bindingUserDiagnostics(d, err)
+	}
+
+	return nil
+}
+
+// bindingUserDiagnostics points the MySQL errors caused by the binding user at its attributes
+func bindingUserDiagnostics(d *schema.ResourceData, err error) diag.Diagnostics {
+	passwordAttribute := bindingPasswordKey
+	switch {
+	case len(d.Get(bindingPasswordGenerationKey).([]any)) > 0:
+		passwordAttribute = bindingPasswordGenerationKey
+	case d.Get(bindingPasswordKey).(string) == "":
+		passwordAttribute = bindingPasswordWOKey
+	}
+
+	return mysqlErrorDiagnostics(err, map[uint16]string{
+		errorCannotUser:       bindingUsernameKey,
+		errorNotValidPassword: passwordAttribute,
+	})
+}
+
+// rawConfigReader is implemented by both schema.ResourceData and schema.ResourceDiff
+type rawConfigReader interface {
+	Get(key string) any
+	GetRawConfigAt(path cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// bindingPassword returns password, or else password_wo, which is only in the configuration
+func bindingPassword(d rawConfigReader) (string, diag.Diagnostics) {
+	if password := d.Get(bindingPasswordKey).(string); password != "" {
+		return password, nil
+	}
+
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(bindingPasswordWOKey))
+	if diags.HasError() {
+		return "", diags
+	}
+	if value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.String) {
+		return "", nil
+	}
+	return value.AsString(), nil
+}
+
+// passwordGenerationFromConfig returns the settings of password_generation, if it is set
+func passwordGenerationFromConfig(d *schema.ResourceData) (passwordGenerationOptions, bool) {
+	blocks := d.Get(bindingPasswordGenerationKey).([]any)
+	if len(blocks) == 0 {
+		return passwordGenerationOptions{}, false
+	}
+
+	settings, ok := blocks[0].(map[string]any)
+	if !ok {
+		// an empty block can be read as nil rather than as the defaults
+		return defaultPasswordGeneration, true
+	}
+	return passwordGenerationOptions{
+		length:  settings[passwordGenerationLengthKey].(int),
+		upper:   settings[passwordGenerationUpperKey].(bool),
+		lower:   settings[passwordGenerationLowerKey].(bool),
+		numeric: settings[passwordGenerationNumericKey].(bool),
+		special: settings[passwordGenerationSpecialKey].(bool),
+	}, true
+}
+
+// identifiedClause returns the authentication part of CREATE USER. IAM users
+// authenticate with a token issued by the cloud provider, so they have no password.
+func identifiedClause(d dialect, password, iamAuthentication string) (string, error) {
+	switch iamAuthentication {
+	case iamAuthenticationAWSRDS:
+		if err := requireFeature(d, featureAWSRDSIAMUsers); err != nil {
+			return "", err
+		}
+		return "IDENTIFIED WITH AWSAuthenticationPlugin AS 'RDS'", nil
+	case iamAuthenticationGCPCloudSQL:
+		if err := requireFeature(d, featureCloudSQLIAMUsers); err != nil {
+			return "", err
+		}
+		return "IDENTIFIED WITH cloudsql_iam_user", nil
+	default:
+		return fmt.Sprintf("IDENTIFIED BY %s", quotedString(password)), nil
+	}
+}
+
+// killSessions ends the connections of a user, which stay open when the account is locked
+func killSessions(ctx context.Context, db *sql.DB, username string) error {
+	ids, err := queryStrings(ctx, db, "SELECT ID FROM information_schema.PROCESSLIST WHERE USER = ?", username)
+	if err != nil {
+		return fmt.Errorf("error listing sessions of %q: %w", username, err)
 	}
 
+	for _, id := range ids {
+		log.Printf("[DEBUG] killing session %s", id)
+		_, err := db.ExecContext(ctx, fmt.Sprintf("KILL CONNECTION %s", id))
+		var mysqlErr *mysql.MySQLError
+		if err != nil && !(errors.As(err, &mysqlErr) && mysqlErr.Number == errorUnknownThread) {
+			return fmt.Errorf("error killing session %s of %q: %w", id, username, err)
+		}
+	}
 	return nil
 }
+
 func userExists(db *sql.DB, name, host string) (bool, error) {
 	log.Println("[DEBUG] ENTRY roleExists()")
 	defer log.Println("[DEBUG] EXIT roleExists()")
@@ -0,0 +1,65 @@
+package csbmysql_test
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Binding user generated password", func() {
+	It("generates a password and a new one when the settings change", func() {
+		const username = "generated-app"
+		var firstPassword string
+
+		generationDefinition := func(length int) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username = "%s"
+  password_generation {
+    length  = %d
+    special = false
+  }
+}
+`, csbmysql.ResourceNameKey, username, length))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: generationDefinition(24),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestMatchResourceAttr(tfStateResourceName, "generated_password", regexpAlphanumeric(24)),
+						func(state *terraform.State) error {
+							firstPassword = state.RootModule().Resources[tfStateResourceName].Primary.Attributes["generated_password"]
+							return checkPasswordsWork(username, map[string]bool{firstPassword: true})(state)
+						},
+					),
+				},
+				{
+					Config: generationDefinition(30),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestMatchResourceAttr(tfStateResourceName, "generated_password", regexpAlphanumeric(30)),
+						func(state *terraform.State) error {
+							secondPassword := state.RootModule().Resources[tfStateResourceName].Primary.Attributes["generated_password"]
+							Expect(secondPassword).NotTo(Equal(firstPassword))
+							return checkPasswordsWork(username, map[string]bool{firstPassword: false, secondPassword: true})(state)
+						},
+					),
+				},
+			},
+		})
+	})
+})
+
+func regexpAlphanumeric(length int) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`^[A-Za-z0-9]{%d}$`, length))
+}
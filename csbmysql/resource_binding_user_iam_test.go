@@ -0,0 +1,33 @@
+package csbmysql_test
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Binding user IAM authentication", func() {
+	It("fails the plan when both a password and IAM authentication are set", func() {
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{
+				{
+					Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username           = "iam-app"
+  password           = "app-password"
+  iam_authentication = "aws_rds"
+}
+`, csbmysql.ResourceNameKey)),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile("only one of `iam_authentication,password"),
+				},
+			},
+		})
+	})
+})
@@ -0,0 +1,29 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Binding user", func() {
+	DescribeTable("identifiedClause",
+		func(version, password, iamAuthentication, expected, expectedErr string) {
+			info, err := parseServerInfo(version, "", false)
+			Expect(err).NotTo(HaveOccurred())
+
+			clause, err := identifiedClause(newDialect(info), password, iamAuthentication)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(expectedErr))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clause).To(Equal(expected))
+		},
+		Entry("password", "8.0.36", "it's-secret", "", `IDENTIFIED BY 'it''s-secret'`, ""),
+		Entry("AWS RDS IAM", "8.0.36", "", iamAuthenticationAWSRDS, "IDENTIFIED WITH AWSAuthenticationPlugin AS 'RDS'", ""),
+		Entry("AWS RDS IAM on MariaDB", "10.11.6-MariaDB", "", iamAuthenticationAWSRDS, "IDENTIFIED WITH AWSAuthenticationPlugin AS 'RDS'", ""),
+		Entry("GCP Cloud SQL IAM", "8.0.36", "", iamAuthenticationGCPCloudSQL, "IDENTIFIED WITH cloudsql_iam_user", ""),
+		Entry("AWS RDS IAM on TiDB", "8.0.11-TiDB-v7.5.0", "", iamAuthenticationAWSRDS, "", "AWS RDS IAM authenticated users unsupported on TiDB 7.5.0"),
+		Entry("GCP Cloud SQL IAM on MariaDB", "10.11.6-MariaDB", "", iamAuthenticationGCPCloudSQL, "", "Cloud SQL IAM authenticated users unsupported on MariaDB 10.11.6"),
+	)
+})
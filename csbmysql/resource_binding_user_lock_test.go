@@ -0,0 +1,104 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Binding user account locking", func() {
+	It("locks and unlocks the account without deleting it", func() {
+		const (
+			username = "suspended-app"
+			password = "suspended-password"
+		)
+		var session *sql.DB
+		lockedDefinition := func(locked bool) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username              = "%s"
+  password              = "%s"
+  account_locked        = %t
+  kill_sessions_on_lock = true
+}
+`, csbmysql.ResourceNameKey, username, password, locked))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: lockedDefinition(false),
+					Check:  checkAccountLocked(username, password, false),
+				},
+				{
+					PreConfig: func() {
+						session = openBindingUserSession(username, password)
+					},
+					Config: lockedDefinition(true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(tfStateResourceName, "account_locked", "true"),
+						checkAccountLocked(username, password, true),
+						func(*terraform.State) error {
+							defer func(db *sql.DB) {
+								_ = db.Close()
+							}(session)
+							Expect(session.Ping()).NotTo(Succeed())
+							return nil
+						},
+					),
+				},
+				{
+					Config: lockedDefinition(false),
+					Check:  checkAccountLocked(username, password, false),
+				},
+			},
+		})
+	})
+})
+
+// openBindingUserSession opens a single connection that stays open until closed
+func openBindingUserSession(username, password string) *sql.DB {
+	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=skip-verify", username, password, dbHost, port, database))
+	Expect(err).NotTo(HaveOccurred())
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	Expect(db.Ping()).To(Succeed())
+	return db
+}
+
+func checkAccountLocked(username, password string, locked bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var accountLocked string
+		Expect(db.QueryRow("SELECT account_locked FROM mysql.user WHERE User = ? AND Host = '%'", username).Scan(&accountLocked)).To(Succeed())
+
+		userDB, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=skip-verify", username, password, dbHost, port, database))
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(userDB)
+
+		if locked {
+			Expect(accountLocked).To(Equal("Y"))
+			Expect(userDB.Ping()).To(MatchError(ContainSubstring("Account is locked")))
+		} else {
+			Expect(accountLocked).To(Equal("N"))
+			Expect(userDB.Ping()).To(Succeed())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,51 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Binding user password policy", func() {
+	BeforeEach(func() {
+		skipOnMySQL57()
+
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(db.Close)
+
+		_, err = db.Exec("INSTALL COMPONENT 'file://component_validate_password'")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			_, err := db.Exec("UNINSTALL COMPONENT 'file://component_validate_password'")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("fails the plan with the rule the password does not meet", func() {
+		const username = "weak-password-app"
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{
+				{
+					Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username = "%s"
+  password = "short"
+}
+`, csbmysql.ResourceNameKey, username)),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`at least 8 characters \(validate_password.length\)`),
+				},
+			},
+		})
+	})
+})
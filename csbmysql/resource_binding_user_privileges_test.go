@@ -0,0 +1,89 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Binding user admin privileges", func() {
+	const (
+		limitedAdmin     = "limited-admin"
+		limitedAdminPass = "limited-admin-password"
+	)
+
+	BeforeEach(func() {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(db.Close)
+
+		for _, statement := range []string{
+			fmt.Sprintf("CREATE USER '%s'@'%%' IDENTIFIED BY '%s'", limitedAdmin, limitedAdminPass),
+			fmt.Sprintf("GRANT SELECT ON *.* TO '%s'@'%%'", limitedAdmin),
+			fmt.Sprintf("GRANT SELECT ON `%s`.* TO '%s'@'%%'", database, limitedAdmin),
+		} {
+			_, err = db.Exec(statement)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		DeferCleanup(func() {
+			_, err := db.Exec(fmt.Sprintf("DROP USER '%s'@'%%'", limitedAdmin))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("fails the plan listing the privileges the admin user is missing", func() {
+		const username = "unprivileged-app"
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: testGetDefinition(csbMySQLProvider+fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username  = "%s"
+  password  = "app-password"
+  read_only = true
+}
+`, csbmysql.ResourceNameKey, username), providerDefinitionWithAdmin(limitedAdmin, limitedAdminPass)),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(fmt.Sprintf("missing privileges: CREATE USER ON \\*\\.\\*, SELECT ON `%s`\\.\\* WITH GRANT OPTION", database)),
+				},
+			},
+		})
+	})
+
+	It("fails the plan of other resources the admin user cannot manage", func() {
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{
+				{
+					Config: testGetDefinition(csbMySQLProvider+fmt.Sprintf(`
+resource "%s" "housekeeping" {
+  name  = "housekeeping"
+  every = "1 DAY"
+  body  = "DELETE FROM previous_table WHERE pk > 1000"
+}
+`, csbmysql.ResourceEventNameKey), providerDefinitionWithAdmin(limitedAdmin, limitedAdminPass)),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(fmt.Sprintf("missing privileges: EVENT ON `%s`\\.\\*", database)),
+				},
+			},
+		})
+	})
+})
+
+func providerDefinitionWithAdmin(username, password string) setDefinitionFunc {
+	return func(config *definition) {
+		config.AdminUser = username
+		config.AdminPass = password
+	}
+}
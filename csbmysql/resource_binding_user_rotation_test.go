@@ -0,0 +1,128 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Binding user password rotation", func() {
+	BeforeEach(skipOnMySQL57)
+
+	It("keeps the old password working until it is discarded", func() {
+		const (
+			username    = "rotated-app"
+			oldPassword = "old-password"
+			newPassword = "new-password"
+		)
+		rotationDefinition := func(password string, discard bool) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username                = "%s"
+  password                = "%s"
+  retain_current_password = true
+  discard_old_password    = %t
+}
+`, csbmysql.ResourceNameKey, username, password, discard))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: rotationDefinition(oldPassword, false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(tfStateResourceName, "old_password_retained", "false"),
+						checkPasswordsWork(username, map[string]bool{oldPassword: true, newPassword: false}),
+					),
+				},
+				{
+					Config: rotationDefinition(newPassword, false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(tfStateResourceName, "old_password_retained", "true"),
+						checkPasswordsWork(username, map[string]bool{oldPassword: true, newPassword: true}),
+					),
+				},
+				{
+					Config: rotationDefinition(newPassword, true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(tfStateResourceName, "old_password_retained", "false"),
+						checkPasswordsWork(username, map[string]bool{oldPassword: false, newPassword: true}),
+					),
+				},
+			},
+		})
+	})
+
+	It("discards the old password when retain_current_password is set back to false", func() {
+		const (
+			username     = "restaged-app"
+			oldPassword  = "old-password"
+			newPassword  = "new-password"
+			nextPassword = "next-password"
+		)
+		rotationDefinition := func(password string, retain bool) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username                = "%s"
+  password                = "%s"
+  retain_current_password = %t
+}
+`, csbmysql.ResourceNameKey, username, password, retain))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: rotationDefinition(oldPassword, false),
+				},
+				{
+					Config: rotationDefinition(newPassword, true),
+					Check:  checkPasswordsWork(username, map[string]bool{oldPassword: true, newPassword: true}),
+				},
+				{
+					Config: rotationDefinition(newPassword, false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(tfStateResourceName, "old_password_retained", "false"),
+						checkPasswordsWork(username, map[string]bool{oldPassword: false, newPassword: true}),
+					),
+				},
+				{
+					Config: rotationDefinition(nextPassword, true),
+					Check:  checkPasswordsWork(username, map[string]bool{newPassword: true, nextPassword: true}),
+				},
+				{
+					Config: rotationDefinition(nextPassword, false),
+					Check:  checkPasswordsWork(username, map[string]bool{newPassword: false, nextPassword: true}),
+				},
+			},
+		})
+	})
+})
+
+func checkPasswordsWork(username string, passwords map[string]bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for password, works := range passwords {
+			db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=skip-verify", username, password, dbHost, port, database))
+			Expect(err).NotTo(HaveOccurred())
+			if works {
+				Expect(db.Ping()).To(Succeed(), "password %q should work", password)
+			} else {
+				Expect(db.Ping()).To(MatchError(ContainSubstring("Access denied")), "password %q should not work", password)
+			}
+			_ = db.Close()
+		}
+		return nil
+	}
+}
@@ -18,7 +18,7 @@ import (
 const (
 	bindingHost      = "%"
 	providerName     = "csbmysql"
-	csbMySQLResource = `
+	csbMySQLProvider = `
 provider "{{.ProviderName}}" {
   host            = "{{.DBHost}}"
   port            = {{.Port}}
@@ -35,8 +35,10 @@ EOF
 "{{.SSLClientPrivateKey}}"
 EOF
   skip_verify     = "{{.SkipVerify}}"
+  require_secure_transport = {{.RequireSecureTransport}}
 }
-
+`
+	csbMySQLResource = csbMySQLProvider + `
 resource "{{.ResourceName}}" "binding_user" {
   username = "{{.Username}}"
   password = "{{.Password}}"
@@ -310,7 +312,24 @@ func initTestProvider() *schema.Provider {
 	testAccProvider := &schema.Provider{
 		Schema: csbmysql.ProviderSchema(),
 		ResourcesMap: map[string]*schema.Resource{
-			csbmysql.ResourceNameKey: csbmysql.ResourceBindingUser(),
+			csbmysql.ResourceNameKey:                   csbmysql.ResourceBindingUser(),
+			csbmysql.ResourceGlobalVariableNameKey:     csbmysql.ResourceGlobalVariable(),
+			csbmysql.ResourceReplicationUserNameKey:    csbmysql.ResourceReplicationUser(),
+			csbmysql.ResourceProxyGrantNameKey:         csbmysql.ResourceProxyGrant(),
+			csbmysql.ResourceRoutineNameKey:            csbmysql.ResourceRoutine(),
+			csbmysql.ResourceViewNameKey:               csbmysql.ResourceView(),
+			csbmysql.ResourceEventNameKey:              csbmysql.ResourceEvent(),
+			csbmysql.ResourceAuditLogFilterNameKey:     csbmysql.ResourceAuditLogFilter(),
+			csbmysql.ResourceAuditLogFilterUserNameKey: csbmysql.ResourceAuditLogFilterUser(),
+			csbmysql.ResourceResourceGroupNameKey:      csbmysql.ResourceResourceGroup(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			csbmysql.DataSourceServerInfoNameKey: csbmysql.DataSourceServerInfo(),
+			csbmysql.DataSourceUsersNameKey:      csbmysql.DataSourceUsers(),
+			csbmysql.DataSourceUserGrantsNameKey: csbmysql.DataSourceUserGrants(),
+			csbmysql.DataSourceDatabasesNameKey:  csbmysql.DataSourceDatabases(),
+			csbmysql.DataSourceTablesNameKey:     csbmysql.DataSourceTables(),
+			csbmysql.DataSourceVariablesNameKey:  csbmysql.DataSourceVariables(),
 		},
 		ConfigureContextFunc: csbmysql.ProviderConfigureContext,
 	}
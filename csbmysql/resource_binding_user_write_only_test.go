@@ -0,0 +1,56 @@
+package csbmysql_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Binding user write-only password", func() {
+	It("sets the password when its version changes without storing it", func() {
+		const (
+			username    = "write-only-app"
+			oldPassword = "old-password"
+			newPassword = "new-password"
+		)
+		writeOnlyDefinition := func(password string, version int) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "binding_user" {
+  username            = "%s"
+  password_wo         = "%s"
+  password_wo_version = %d
+}
+`, csbmysql.ResourceNameKey, username, password, version))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: writeOnlyDefinition(oldPassword, 1),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckNoResourceAttr(tfStateResourceName, "password_wo"),
+						resource.TestCheckResourceAttr(tfStateResourceName, "password", ""),
+						checkPasswordsWork(username, map[string]bool{oldPassword: true}),
+					),
+				},
+				{
+					Config: writeOnlyDefinition(newPassword, 1),
+					Check:  checkPasswordsWork(username, map[string]bool{oldPassword: true, newPassword: false}),
+				},
+				{
+					Config: writeOnlyDefinition(newPassword, 2),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckNoResourceAttr(tfStateResourceName, "password_wo"),
+						checkPasswordsWork(username, map[string]bool{oldPassword: false, newPassword: true}),
+					),
+				},
+			},
+		})
+	})
+})
@@ -0,0 +1,336 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	eventDatabaseKey     = "database"
+	eventNameKey         = "name"
+	eventAtKey           = "at"
+	eventEveryKey        = "every"
+	eventStartsKey       = "starts"
+	eventEndsKey         = "ends"
+	eventBodyKey         = "body"
+	eventPreserveKey     = "on_completion_preserve"
+	eventEnabledKey      = "enabled"
+	eventDefinerKey      = "definer"
+	eventCommentKey      = "comment"
+	eventTimestampFormat = "YYYY-MM-DD hh:mm:ss"
+)
+
+var (
+	eventTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`)
+	eventIntervalPattern  = regexp.MustCompile(`(?i)^\d+ (SECOND|MINUTE|HOUR|DAY|WEEK|MONTH|QUARTER|YEAR)$`)
+)
+
+func ResourceEvent() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceEventSchema,
+		CreateContext: resourceEventCreate,
+		ReadContext:   resourceEventRead,
+		UpdateContext: resourceEventUpdate,
+		DeleteContext: resourceEventDelete,
+		CustomizeDiff: resourceEventCustomizeDiff,
+		Description: "A scheduled event, run once AT a time or EVERY interval. Events only run while event_scheduler is ON. " +
+			"A one-time event is dropped by the server after it runs unless on_completion_preserve is set.",
+	}
+}
+
+var resourceEventSchema = map[string]*schema.Schema{
+	eventDatabaseKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "Database to create the event in. Defaults to the database of the provider.",
+	},
+	eventNameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	eventAtKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: []string{eventAtKey, eventEveryKey},
+		ValidateFunc: validation.StringMatch(eventTimestampPattern, "must be a timestamp in the form "+eventTimestampFormat),
+		Description:  "Run the event once at this time, in the form " + eventTimestampFormat + ".",
+	},
+	eventEveryKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: []string{eventAtKey, eventEveryKey},
+		ValidateFunc: validation.StringMatch(eventIntervalPattern, "must be a quantity and a unit, for example \"1 DAY\""),
+		DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+			return strings.EqualFold(oldValue, newValue)
+		},
+		Description: "Run the event repeatedly at this interval, for example \"1 DAY\" or \"6 HOUR\".",
+	},
+	eventStartsKey: {
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{eventAtKey},
+		ValidateFunc:  validation.StringMatch(eventTimestampPattern, "must be a timestamp in the form "+eventTimestampFormat),
+		Description:   "First time a recurring event runs. Defaults to the time it is created.",
+	},
+	eventEndsKey: {
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{eventAtKey},
+		ValidateFunc:  validation.StringMatch(eventTimestampPattern, "must be a timestamp in the form "+eventTimestampFormat),
+		Description:   "Time after which a recurring event no longer runs.",
+	},
+	eventBodyKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+			return strings.TrimSpace(oldValue) == strings.TrimSpace(newValue)
+		},
+		Description: "Statement to run, such as a single statement or a BEGIN ... END block.",
+	},
+	eventPreserveKey: {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	},
+	eventEnabledKey: {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	},
+	eventDefinerKey: {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		DiffSuppressFunc: suppressEquivalentAccount,
+		Description:      "Account whose privileges the event runs with, as user@host. Defaults to the admin user of the provider.",
+	},
+	eventCommentKey: {
+		Type:     schema.TypeString,
+		Optional: true,
+	},
+}
+
+func resourceEventCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceEventCreate()")
+	defer log.Println("[DEBUG] EXIT resourceEventCreate()")
+
+	cf := m.(connectionFactory)
+
+	database := d.Get(eventDatabaseKey).(string)
+	if database == "" {
+		database = cf.database
+	}
+	name := d.Get(eventNameKey).(string)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, eventStatement("CREATE", d, database)); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating event %s.%s: %w", database, name, err))
+	}
+
+	if err := d.Set(eventDatabaseKey, database); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s.%s", database, name))
+
+	return resourceEventRead(ctx, d, m)
+}
+
+// resourceEventRead also warns when the event scheduler is not running
+func resourceEventRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceEventRead()")
+	defer log.Println("[DEBUG] EXIT resourceEventRead()")
+
+	database := d.Get(eventDatabaseKey).(string)
+	name := d.Get(eventNameKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	var (
+		body, eventType, status, onCompletion, definer, notes string
+		executeAt, intervalValue, intervalField, starts, ends sql.NullString
+	)
+	err = db.QueryRowContext(ctx, `
+SELECT EVENT_DEFINITION, EVENT_TYPE, EXECUTE_AT, INTERVAL_VALUE, INTERVAL_FIELD, STARTS, ENDS, STATUS, ON_COMPLETION, DEFINER, EVENT_COMMENT
+FROM information_schema.EVENTS
+WHERE EVENT_SCHEMA = ? AND EVENT_NAME = ?`, database, name).
+		Scan(&body, &eventType, &executeAt, &intervalValue, &intervalField, &starts, &ends, &status, &onCompletion, &definer, &notes)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] event %s.%s no longer exists", database, name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading event %s.%s: %w", database, name, err))
+	}
+
+	values := map[string]any{
+		eventBodyKey:     strings.TrimSpace(body),
+		eventAtKey:       executeAt.String,
+		eventEveryKey:    "",
+		eventStartsKey:   starts.String,
+		eventEndsKey:     ends.String,
+		eventPreserveKey: onCompletion == "PRESERVE",
+		eventEnabledKey:  status == "ENABLED",
+		eventDefinerKey:  definer,
+		eventCommentKey:  notes,
+	}
+	if eventType == "RECURRING" {
+		values[eventEveryKey] = fmt.Sprintf("%s %s", intervalValue.String, intervalField.String)
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return eventSchedulerWarning(ctx, db)
+}
+
+func resourceEventUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceEventUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceEventUpdate()")
+
+	database := d.Get(eventDatabaseKey).(string)
+	name := d.Get(eventNameKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, eventStatement("ALTER", d, database)); err != nil {
+		return diag.FromErr(fmt.Errorf("error altering event %s.%s: %w", database, name, err))
+	}
+
+	return resourceEventRead(ctx, d, m)
+}
+
+func resourceEventDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceEventDelete()")
+	defer log.Println("[DEBUG] EXIT resourceEventDelete()")
+
+	database := d.Get(eventDatabaseKey).(string)
+	name := d.Get(eventNameKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP EVENT IF EXISTS %s.%s", quotedIdentifier(database), quotedIdentifier(name))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// eventStatement builds CREATE EVENT or ALTER EVENT, which take the same clauses
+func eventStatement(verb string, d *schema.ResourceData, database string) string {
+	var b strings.Builder
+	b.WriteString(verb)
+	if definer := d.Get(eventDefinerKey).(string); definer != "" {
+		fmt.Fprintf(&b, " DEFINER = %s", quotedAccount(definer))
+	}
+	fmt.Fprintf(&b, " EVENT %s.%s ON SCHEDULE", quotedIdentifier(database), quotedIdentifier(d.Get(eventNameKey).(string)))
+
+	if at := d.Get(eventAtKey).(string); at != "" {
+		fmt.Fprintf(&b, " AT %s", quotedString(at))
+	} else {
+		fmt.Fprintf(&b, " EVERY %s", strings.ToUpper(d.Get(eventEveryKey).(string)))
+		if starts := d.Get(eventStartsKey).(string); starts != "" {
+			fmt.Fprintf(&b, " STARTS %s", quotedString(starts))
+		}
+		if ends := d.Get(eventEndsKey).(string); ends != "" {
+			fmt.Fprintf(&b, " ENDS %s", quotedString(ends))
+		}
+	}
+
+	if d.Get(eventPreserveKey).(bool) {
+		b.WriteString(" ON COMPLETION PRESERVE")
+	} else {
+		b.WriteString(" ON COMPLETION NOT PRESERVE")
+	}
+	if d.Get(eventEnabledKey).(bool) {
+		b.WriteString(" ENABLE")
+	} else {
+		b.WriteString(" DISABLE")
+	}
+	fmt.Fprintf(&b, " COMMENT %s", quotedString(d.Get(eventCommentKey).(string)))
+	fmt.Fprintf(&b, " DO %s", strings.TrimSpace(d.Get(eventBodyKey).(string)))
+
+	return b.String()
+}
+
+// resourceEventCustomizeDiff checks that the admin user has the EVENT privilege on the database
+func resourceEventCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges(eventAtKey, eventEveryKey, eventStartsKey, eventEndsKey, eventBodyKey, eventPreserveKey, eventEnabledKey, eventDefinerKey, eventCommentKey) {
+		return nil
+	}
+
+	database := d.Get(eventDatabaseKey).(string)
+	if database == "" {
+		database = cf.database
+	}
+	action := fmt.Sprintf("create or alter event %s.%s", database, d.Get(eventNameKey).(string))
+	return checkAdminPrivilegesAtPlan(ctx, cf, action, []requiredPrivilege{{privilege: "EVENT", database: database}})
+}
+
+// eventSchedulerWarning is returned by Read, so that it is shown when an event is created or changed,
+// and when refreshing before planning changes to existing events
+func eventSchedulerWarning(ctx context.Context, db *sql.DB) diag.Diagnostics {
+	var scheduler string
+	if err := db.QueryRowContext(ctx, "SELECT @@GLOBAL.event_scheduler").Scan(&scheduler); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading event_scheduler: %w", err))
+	}
+	if isEnabled(scheduler) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Event scheduler is not running",
+		Detail: fmt.Sprintf("event_scheduler is %s, so events are created but never run. "+
+			"Set it to ON, for example with the csbmysql_global_variable resource or the server configuration.", scheduler),
+	}}
+}
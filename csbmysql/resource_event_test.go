@@ -0,0 +1,132 @@
+package csbmysql_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Event resource", func() {
+	It("creates and alters a recurring event", func() {
+		resourceName := fmt.Sprintf("%s.housekeeping", csbmysql.ResourceEventNameKey)
+		eventDefinition := func(every string, enabled bool) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "housekeeping" {
+  name    = "housekeeping"
+  every   = "%s"
+  enabled = %t
+  body    = "DELETE FROM previous_table WHERE pk > 1000"
+}
+`, csbmysql.ResourceEventNameKey, every, enabled))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkEventIsDestroyed("housekeeping"),
+			Steps: []resource.TestStep{
+				{
+					Config: eventDefinition("1 DAY", false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "database", database),
+						resource.TestCheckResourceAttrSet(resourceName, "starts"),
+						checkEvent("housekeeping", "1", "DAY", "DISABLED"),
+					),
+				},
+				{
+					Config: eventDefinition("6 hour", true),
+					Check:  checkEvent("housekeeping", "6", "HOUR", "ENABLED"),
+				},
+			},
+		})
+	})
+
+	When("the event scheduler is OFF", func() {
+		BeforeEach(func() {
+			db, err := sql.Open("mysql", adminUserURI)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(db.Close)
+
+			var scheduler string
+			Expect(db.QueryRow("SELECT @@GLOBAL.event_scheduler").Scan(&scheduler)).To(Succeed())
+			_, err = db.Exec("SET GLOBAL event_scheduler = OFF")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				_, err := db.Exec(fmt.Sprintf("SET GLOBAL event_scheduler = %s", scheduler))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("warns that the event will not run", func() {
+			db, err := sql.Open("mysql", adminUserURI)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(db.Close)
+
+			diags := csbmysql.EventSchedulerWarning(context.Background(), db)
+			Expect(diags).To(HaveLen(1))
+			Expect(diags[0].Severity).To(Equal(diag.Warning))
+			Expect(diags[0].Detail).To(HavePrefix("event_scheduler is OFF"))
+		})
+
+		It("still creates the event", func() {
+			resource.Test(GinkgoT(), resource.TestCase{
+				IsUnitTest:        true,
+				ProviderFactories: getTestProviderFactories(initTestProvider()),
+				CheckDestroy:      checkEventIsDestroyed("cleanup"),
+				Steps: []resource.TestStep{{
+					Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "cleanup" {
+  name  = "cleanup"
+  every = "1 DAY"
+  body  = "DELETE FROM previous_table WHERE pk > 1000"
+}
+`, csbmysql.ResourceEventNameKey)),
+					Check: checkEvent("cleanup", "1", "DAY", "ENABLED"),
+				}},
+			})
+		})
+	})
+})
+
+func checkEvent(name, intervalValue, intervalField, status string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var actualValue, actualField, actualStatus string
+		Expect(db.QueryRow(
+			"SELECT INTERVAL_VALUE, INTERVAL_FIELD, STATUS FROM information_schema.EVENTS WHERE EVENT_SCHEMA = ? AND EVENT_NAME = ?",
+			database, name,
+		).Scan(&actualValue, &actualField, &actualStatus)).To(Succeed())
+		Expect(actualValue).To(Equal(intervalValue))
+		Expect(actualField).To(Equal(intervalField))
+		Expect(actualStatus).To(Equal(status))
+		return nil
+	}
+}
+
+func checkEventIsDestroyed(name string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var count int
+		Expect(db.QueryRow("SELECT COUNT(*) FROM information_schema.EVENTS WHERE EVENT_SCHEMA = ? AND EVENT_NAME = ?", database, name).Scan(&count)).To(Succeed())
+		Expect(count).To(BeZero())
+		return nil
+	}
+}
@@ -0,0 +1,299 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	globalVariableNameKey        = "name"
+	globalVariableValueKey       = "value"
+	globalVariablePersistOnlyKey = "persist_only"
+
+	// errorVariableIsReadOnly is ER_INCORRECT_GLOBAL_LOCAL_VAR, returned when setting a read-only variable
+	errorVariableIsReadOnly = 1238
+	// errorUnknownSystemVariable is ER_UNKNOWN_SYSTEM_VARIABLE
+	errorUnknownSystemVariable = 1193
+)
+
+var (
+	variableNamePattern    = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+	numericVariablePattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+	// setVariables are the variables whose value is a list of flags that the server reads back in its own order
+	setVariables = map[string]bool{
+		"sql_mode":                 true,
+		"optimizer_switch":         true,
+		"optimizer_trace":          true,
+		"optimizer_trace_features": true,
+		"log_output":               true,
+		"replica_type_conversions": true,
+		"slave_type_conversions":   true,
+	}
+)
+
+func ResourceGlobalVariable() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceGlobalVariableSchema,
+		CreateContext: resourceGlobalVariableCreate,
+		ReadContext:   resourceGlobalVariableRead,
+		UpdateContext: resourceGlobalVariableUpdate,
+		DeleteContext: resourceGlobalVariableDelete,
+		CustomizeDiff: resourceGlobalVariableCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "A global system variable persisted with SET PERSIST. Only available on self-managed MySQL 8.",
+	}
+}
+
+var resourceGlobalVariableSchema = map[string]*schema.Schema{
+	globalVariableNameKey: {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringMatch(variableNamePattern, "must be the name of a system variable"),
+	},
+	globalVariableValueKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+			return strings.TrimSpace(oldValue) == strings.TrimSpace(newValue)
+		},
+		Description: "The server reads back booleans as ON or OFF and sets such as sql_mode in its own order, so for those variables equivalent values like 1 and ON do not cause a diff.",
+	},
+	globalVariablePersistOnlyKey: {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Use SET PERSIST_ONLY, so that the value only takes effect at the next restart. Read-only variables are always set this way.",
+	},
+}
+
+func resourceGlobalVariableCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceGlobalVariableCreate()")
+	defer log.Println("[DEBUG] EXIT resourceGlobalVariableCreate()")
+
+	if diags := persistGlobalVariable(ctx, d, m); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(d.Get(globalVariableNameKey).(string))
+
+	return resourceGlobalVariableRead(ctx, d, m)
+}
+
+func resourceGlobalVariableRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceGlobalVariableRead()")
+	defer log.Println("[DEBUG] EXIT resourceGlobalVariableRead()")
+
+	name := d.Id()
+
+	cf := m.(connectionFactory)
+	if err := requireFeature(cf.dialect, featurePersistVariables); err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	var value string
+	err = db.QueryRowContext(ctx, "SELECT VARIABLE_VALUE FROM performance_schema.persisted_variables WHERE VARIABLE_NAME = ?", name).Scan(&value)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] variable %s is no longer persisted", name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading persisted variable %q: %w", name, err))
+	}
+
+	// the configured value is kept when the server reads it back in another form
+	if configured := d.Get(globalVariableValueKey).(string); configured != "" {
+		boolean, err := isBooleanVariable(ctx, db, name, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if equivalentVariableValues(name, configured, value, boolean) {
+			value = configured
+		}
+	}
+
+	if err := d.Set(globalVariableNameKey, name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(globalVariableValueKey, value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGlobalVariableUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceGlobalVariableUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceGlobalVariableUpdate()")
+
+	if diags := persistGlobalVariable(ctx, d, m); diags.HasError() {
+		return diags
+	}
+
+	return resourceGlobalVariableRead(ctx, d, m)
+}
+
+// resourceGlobalVariableDelete removes the variable from the persisted configuration.
+// The value in use by the running server is not changed until the next restart.
+func resourceGlobalVariableDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceGlobalVariableDelete()")
+	defer log.Println("[DEBUG] EXIT resourceGlobalVariableDelete()")
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("RESET PERSIST IF EXISTS %s", d.Id())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func persistGlobalVariable(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	name := d.Get(globalVariableNameKey).(string)
+	value := d.Get(globalVariableValueKey).(string)
+	persistOnly := d.Get(globalVariablePersistOnlyKey).(bool)
+
+	cf := m.(connectionFactory)
+	if err := requireFeature(cf.dialect, featurePersistVariables); err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if !persistOnly {
+		_, err = db.ExecContext(ctx, fmt.Sprintf("SET PERSIST %s = %s", name, variableValueLiteral(value)))
+		if err == nil {
+			return nil
+		}
+
+		var mysqlErr *mysql.MySQLError
+		if !errors.As(err, &mysqlErr) || mysqlErr.Number != errorVariableIsReadOnly {
+			return diag.FromErr(err)
+		}
+		log.Printf("[DEBUG] variable %s is read-only, persisting it for the next restart", name)
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("SET PERSIST_ONLY %s = %s", name, variableValueLiteral(value))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// variableValueLiteral leaves numbers unquoted, as numeric variables reject string values
+func variableValueLiteral(value string) string {
+	if numericVariablePattern.MatchString(value) {
+		return value
+	}
+	return quotedString(value)
+}
+
+// isBooleanVariable tells booleans, which are persisted as ON or OFF but selected as 1 or 0, from
+// enums such as gtid_mode that also have ON and OFF values, but where 1 is another member
+func isBooleanVariable(ctx context.Context, db *sql.DB, name, persisted string) (bool, error) {
+	if (persisted != "ON" && persisted != "OFF") || !variableNamePattern.MatchString(name) {
+		return false, nil
+	}
+
+	var current sql.NullString
+	err := db.QueryRowContext(ctx, fmt.Sprintf("SELECT @@GLOBAL.%s", name)).Scan(&current)
+	var mysqlErr *mysql.MySQLError
+	switch {
+	case errors.As(err, &mysqlErr) && mysqlErr.Number == errorUnknownSystemVariable:
+		log.Printf("[DEBUG] variable %s is persisted but not known to the running server", name)
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("error reading variable %q: %w", name, err)
+	}
+	return current.String == "0" || current.String == "1", nil
+}
+
+// equivalentVariableValues tells whether the server reads a configured value back in another form.
+// Only booleans and sets are compared loosely, as for any other variable, like max_connections or
+// init_connect, a value written differently is a different value.
+func equivalentVariableValues(name, configured, readBack string, boolean bool) bool {
+	configured = strings.TrimSpace(configured)
+	if configured == readBack {
+		return true
+	}
+
+	switch {
+	case boolean:
+		return booleanVariableValue(configured) == readBack
+	case setVariables[strings.ToLower(name)]:
+		return sortedSetMembers(configured) == sortedSetMembers(readBack)
+	}
+	return false
+}
+
+// booleanVariableValue returns the ON or OFF a boolean variable reads back as
+func booleanVariableValue(value string) string {
+	switch strings.ToUpper(value) {
+	case "ON", "TRUE", "1":
+		return "ON"
+	case "OFF", "FALSE", "0":
+		return "OFF"
+	}
+	return value
+}
+
+func sortedSetMembers(value string) string {
+	members := strings.Split(strings.ToUpper(value), ",")
+	for i := range members {
+		members[i] = strings.TrimSpace(members[i])
+	}
+	slices.Sort(members)
+	return strings.Join(members, ",")
+}
+
+// resourceGlobalVariableCustomizeDiff checks that the admin user can persist the variable.
+// SUPER still allows it on MySQL 8 for admin users that have not been given the dynamic privileges.
+func resourceGlobalVariableCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok || (d.Id() != "" && !d.HasChanges(globalVariableValueKey, globalVariablePersistOnlyKey)) {
+		return nil
+	}
+
+	required := []requiredPrivilege{{privilege: "SYSTEM_VARIABLES_ADMIN", alternatives: []requiredPrivilege{{privilege: "SUPER"}}}}
+	if d.Get(globalVariablePersistOnlyKey).(bool) {
+		required = append(required, requiredPrivilege{privilege: "PERSIST_RO_VARIABLES_ADMIN"})
+	}
+	return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("persist variable %s", d.Get(globalVariableNameKey).(string)), required)
+}
@@ -0,0 +1,22 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Global variable", func() {
+	DescribeTable("equivalentVariableValues",
+		func(name, configured, readBack string, boolean, equivalent bool) {
+			Expect(equivalentVariableValues(name, configured, readBack, boolean)).To(Equal(equivalent))
+		},
+		Entry("boolean as a number", "autocommit", "1", "ON", true, true),
+		Entry("boolean as a keyword", "autocommit", "false", "OFF", true, true),
+		Entry("boolean with another value", "autocommit", "1", "OFF", true, false),
+		Entry("number that is not a boolean", "max_connections", "1", "ON", false, false),
+		Entry("set in another order", "sql_mode", "no_zero_date, strict_trans_tables", "STRICT_TRANS_TABLES,NO_ZERO_DATE", false, true),
+		Entry("list that is not a set", "tls_ciphersuites", "TLS_AES_256_GCM_SHA384,TLS_AES_128_GCM_SHA256", "TLS_AES_128_GCM_SHA256,TLS_AES_256_GCM_SHA384", false, false),
+		Entry("same value with spaces", "init_connect", " SET NAMES utf8mb4 ", "SET NAMES utf8mb4", false, true),
+		Entry("different numbers", "max_connections", "200", "250", false, false),
+	)
+})
@@ -0,0 +1,110 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Global variable resource", func() {
+	BeforeEach(skipOnMySQL57)
+
+	It("persists a global variable", func() {
+		resourceName := fmt.Sprintf("%s.max_connections", csbmysql.ResourceGlobalVariableNameKey)
+		globalVariableDefinition := func(value string) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "max_connections" {
+  name  = "max_connections"
+  value = "%s"
+}
+`, csbmysql.ResourceGlobalVariableNameKey, value))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkVariableIsNotPersisted("max_connections"),
+			Steps: []resource.TestStep{
+				{
+					Config: globalVariableDefinition("200"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "value", "200"),
+						checkGlobalVariable("max_connections", "200"),
+					),
+				},
+				{
+					Config: globalVariableDefinition("250"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "value", "250"),
+						checkGlobalVariable("max_connections", "250"),
+					),
+				},
+				{
+					ResourceName:      resourceName,
+					ImportState:       true,
+					ImportStateVerify: true,
+					// persist_only only controls how the value is set, so it cannot be read back
+					ImportStateVerifyIgnore: []string{"persist_only"},
+				},
+			},
+		})
+	})
+
+	It("does not report a diff for a boolean read back as ON", func() {
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkVariableIsNotPersisted("log_bin_trust_function_creators"),
+			Steps: []resource.TestStep{{
+				Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "trust_function_creators" {
+  name  = "log_bin_trust_function_creators"
+  value = "1"
+}
+`, csbmysql.ResourceGlobalVariableNameKey)),
+				Check: checkGlobalVariable("log_bin_trust_function_creators", "ON"),
+			}},
+		})
+	})
+})
+
+func checkGlobalVariable(name, expected string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var persisted, current string
+		Expect(db.QueryRow("SELECT VARIABLE_VALUE FROM performance_schema.persisted_variables WHERE VARIABLE_NAME = ?", name).Scan(&persisted)).To(Succeed())
+		Expect(persisted).To(Equal(expected))
+		Expect(db.QueryRow("SELECT VARIABLE_VALUE FROM performance_schema.global_variables WHERE VARIABLE_NAME = ?", name).Scan(&current)).To(Succeed())
+		Expect(current).To(Equal(expected))
+		return nil
+	}
+}
+
+func checkVariableIsNotPersisted(name string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		rows, err := db.Query("SELECT 1 FROM performance_schema.persisted_variables WHERE VARIABLE_NAME = ?", name)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(rows *sql.Rows) {
+			_ = rows.Close()
+		}(rows)
+		Expect(rows.Next()).To(BeFalse())
+		return nil
+	}
+}
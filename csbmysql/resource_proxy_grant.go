@@ -0,0 +1,246 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	proxyGrantProxyUsernameKey  = "proxy_username"
+	proxyGrantProxyHostKey      = "proxy_host"
+	proxyGrantTargetUsernameKey = "target_username"
+	proxyGrantTargetHostKey     = "target_host"
+	proxyGrantGrantOptionKey    = "grant_option"
+	proxyGrantDefaultHostAll    = "%"
+)
+
+func ResourceProxyGrant() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceProxyGrantSchema,
+		CreateContext: resourceProxyGrantCreate,
+		ReadContext:   resourceProxyGrantRead,
+		DeleteContext: resourceProxyGrantDelete,
+		CustomizeDiff: resourceProxyGrantCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProxyGrantImport,
+		},
+		Description: "Allows a proxy user, such as a connection pooler account, to log in as a target user with GRANT PROXY. " +
+			"Import with an ID of the form proxy_username@proxy_host/target_username@target_host.",
+	}
+}
+
+var resourceProxyGrantSchema = map[string]*schema.Schema{
+	proxyGrantProxyUsernameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	proxyGrantProxyHostKey: {
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Default:  proxyGrantDefaultHostAll,
+	},
+	proxyGrantTargetUsernameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	proxyGrantTargetHostKey: {
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Default:  proxyGrantDefaultHostAll,
+	},
+	proxyGrantGrantOptionKey: {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     false,
+		Description: "Allow the proxy user to grant the PROXY privilege on the target user to others.",
+	},
+}
+
+type proxyGrant struct {
+	proxyUsername, proxyHost, targetUsername, targetHost string
+}
+
+func proxyGrantFromResourceData(d *schema.ResourceData) proxyGrant {
+	return proxyGrant{
+		proxyUsername:  d.Get(proxyGrantProxyUsernameKey).(string),
+		proxyHost:      d.Get(proxyGrantProxyHostKey).(string),
+		targetUsername: d.Get(proxyGrantTargetUsernameKey).(string),
+		targetHost:     d.Get(proxyGrantTargetHostKey).(string),
+	}
+}
+
+func (p proxyGrant) id() string {
+	return fmt.Sprintf("%s@%s/%s@%s", p.proxyUsername, p.proxyHost, p.targetUsername, p.targetHost)
+}
+
+func (p proxyGrant) proxyAccount() string {
+	return fmt.Sprintf("%s@%s", quotedString(p.proxyUsername), quotedString(p.proxyHost))
+}
+
+func (p proxyGrant) targetAccount() string {
+	return fmt.Sprintf("%s@%s", quotedString(p.targetUsername), quotedString(p.targetHost))
+}
+
+func parseProxyGrantID(id string) (proxyGrant, error) {
+	proxy, target, found := strings.Cut(id, "/")
+	if !found {
+		return proxyGrant{}, fmt.Errorf("invalid proxy grant ID %q, expected proxy_username@proxy_host/target_username@target_host", id)
+	}
+
+	splitAccount := func(account string) (string, string, error) {
+		i := strings.LastIndex(account, "@")
+		if i < 0 {
+			return "", "", fmt.Errorf("invalid proxy grant ID %q, expected proxy_username@proxy_host/target_username@target_host", id)
+		}
+		return account[:i], account[i+1:], nil
+	}
+
+	var (
+		result proxyGrant
+		err    error
+	)
+	if result.proxyUsername, result.proxyHost, err = splitAccount(proxy); err != nil {
+		return proxyGrant{}, err
+	}
+	if result.targetUsername, result.targetHost, err = splitAccount(target); err != nil {
+		return proxyGrant{}, err
+	}
+	return result, nil
+}
+
+func resourceProxyGrantCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceProxyGrantCreate()")
+	defer log.Println("[DEBUG] EXIT resourceProxyGrantCreate()")
+
+	grant := proxyGrantFromResourceData(d)
+	grantOption := d.Get(proxyGrantGrantOptionKey).(bool)
+
+	cf := m.(connectionFactory)
+	if err := requireFeature(cf.dialect, featureProxyUsers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	statement := fmt.Sprintf("GRANT PROXY ON %s TO %s", grant.targetAccount(), grant.proxyAccount())
+	if grantOption {
+		statement += " WITH GRANT OPTION"
+	}
+	if _, err := db.ExecContext(ctx, statement); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(grant.id())
+
+	return resourceProxyGrantRead(ctx, d, m)
+}
+
+func resourceProxyGrantRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceProxyGrantRead()")
+	defer log.Println("[DEBUG] EXIT resourceProxyGrantRead()")
+
+	grant, err := parseProxyGrantID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	var withGrant bool
+	err = db.QueryRowContext(ctx,
+		"SELECT With_grant FROM mysql.proxies_priv WHERE User = ? AND Host = ? AND Proxied_user = ? AND Proxied_host = ?",
+		grant.proxyUsername, grant.proxyHost, grant.targetUsername, grant.targetHost,
+	).Scan(&withGrant)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] proxy grant %s no longer exists", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading proxy grant %q: %w", d.Id(), err))
+	}
+
+	values := map[string]any{
+		proxyGrantProxyUsernameKey:  grant.proxyUsername,
+		proxyGrantProxyHostKey:      grant.proxyHost,
+		proxyGrantTargetUsernameKey: grant.targetUsername,
+		proxyGrantTargetHostKey:     grant.targetHost,
+		proxyGrantGrantOptionKey:    withGrant,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceProxyGrantDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceProxyGrantDelete()")
+	defer log.Println("[DEBUG] EXIT resourceProxyGrantDelete()")
+
+	grant := proxyGrantFromResourceData(d)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("REVOKE PROXY ON %s FROM %s", grant.targetAccount(), grant.proxyAccount())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProxyGrantImport(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	if _, err := parseProxyGrantID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceProxyGrantCustomizeDiff checks that the admin user has PROXY WITH GRANT OPTION on the target user
+func resourceProxyGrantCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok || d.Id() != "" {
+		return nil
+	}
+
+	target := fmt.Sprintf("%s@%s", d.Get(proxyGrantTargetUsernameKey).(string), d.Get(proxyGrantTargetHostKey).(string))
+	return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("grant PROXY on %s", target), []requiredPrivilege{
+		{privilege: "PROXY", proxied: target, grantOption: true},
+	})
+}
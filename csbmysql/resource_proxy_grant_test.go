@@ -0,0 +1,92 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Proxy grant resource", func() {
+	It("lets the proxy user log in as the target user", func() {
+		const (
+			proxyUsername  = "pooler"
+			targetUsername = "pooled_app"
+		)
+		resourceName := fmt.Sprintf("%s.pooler", csbmysql.ResourceProxyGrantNameKey)
+		proxyGrantDefinition := testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%[1]s" "proxy" {
+  username = "%[3]s"
+  password = "proxy-password"
+}
+
+resource "%[1]s" "target" {
+  username = "%[4]s"
+  password = "target-password"
+}
+
+resource "%[2]s" "pooler" {
+  proxy_username  = %[1]s.proxy.username
+  target_username = %[1]s.target.username
+}
+`, csbmysql.ResourceReplicationUserNameKey, csbmysql.ResourceProxyGrantNameKey, proxyUsername, targetUsername))
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(proxyUsername, true),
+			Steps: []resource.TestStep{
+				{
+					Config: proxyGrantDefinition,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s@%%/%s@%%", proxyUsername, targetUsername)),
+						resource.TestCheckResourceAttr(resourceName, "grant_option", "false"),
+						checkProxyGrant(proxyUsername, targetUsername, true),
+					),
+				},
+				{
+					ResourceName:      resourceName,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%[1]s" "proxy" {
+  username = "%[2]s"
+  password = "proxy-password"
+}
+
+resource "%[1]s" "target" {
+  username = "%[3]s"
+  password = "target-password"
+}
+`, csbmysql.ResourceReplicationUserNameKey, proxyUsername, targetUsername)),
+					Check: checkProxyGrant(proxyUsername, targetUsername, false),
+				},
+			},
+		})
+	})
+})
+
+func checkProxyGrant(proxyUsername, targetUsername string, expected bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var count int
+		Expect(db.QueryRow(
+			"SELECT COUNT(*) FROM mysql.proxies_priv WHERE User = ? AND Host = '%' AND Proxied_user = ? AND Proxied_host = '%'",
+			proxyUsername, targetUsername,
+		).Scan(&count)).To(Succeed())
+		Expect(count > 0).To(Equal(expected))
+		return nil
+	}
+}
@@ -0,0 +1,250 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	replicationUsernameKey    = "username"
+	replicationPasswordKey    = "password"
+	replicationHostKey        = "host"
+	replicationRequireX509Key = "require_x509"
+	replicationSSLSubjectKey  = "ssl_subject"
+	replicationSSLIssuerKey   = "ssl_issuer"
+	replicationSSLCipherKey   = "ssl_cipher"
+	replicationPrivileges     = "REPLICATION SLAVE, REPLICATION CLIENT"
+	replicationDefaultHostAll = "%"
+)
+
+func ResourceReplicationUser() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceReplicationUserSchema,
+		CreateContext: resourceReplicationUserCreate,
+		ReadContext:   resourceReplicationUserRead,
+		UpdateContext: resourceReplicationUserUpdate,
+		DeleteContext: resourceReplicationUserDelete,
+		CustomizeDiff: resourceReplicationUserCustomizeDiff,
+		Description:   "A user for read replicas, with REPLICATION SLAVE and REPLICATION CLIENT on *.* and TLS always required",
+	}
+}
+
+var resourceReplicationUserSchema = map[string]*schema.Schema{
+	replicationUsernameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	replicationPasswordKey: {
+		Type:      schema.TypeString,
+		Required:  true,
+		Sensitive: true,
+	},
+	replicationHostKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     replicationDefaultHostAll,
+		Description: "Host the replica connects from.",
+	},
+	replicationRequireX509Key: {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Require the replica to present a valid client certificate, as configured with SOURCE_SSL_CERT.",
+	},
+	replicationSSLSubjectKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Require a client certificate with this subject.",
+	},
+	replicationSSLIssuerKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Require a client certificate issued by this issuer.",
+	},
+	replicationSSLCipherKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Require the connection to use this cipher, as configured with SOURCE_SSL_CIPHER.",
+	},
+}
+
+func resourceReplicationUserCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	createBindingMutex.Lock()
+	defer createBindingMutex.Unlock()
+
+	log.Println("[DEBUG] ENTRY resourceReplicationUserCreate()")
+	defer log.Println("[DEBUG] EXIT resourceReplicationUserCreate()")
+
+	username := d.Get(replicationUsernameKey).(string)
+	password := d.Get(replicationPasswordKey).(string)
+	host := d.Get(replicationHostKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	log.Println("[DEBUG] create replication user")
+	identification := fmt.Sprintf("IDENTIFIED BY %s", quotedString(password))
+	if _, err := tx.ExecContext(ctx, cf.dialect.createUserStatement(username, host, identification, replicationTLSRequirement(d))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Replication privileges are global, so unlike binding users there is no grant on the service database
+	if _, err := tx.ExecContext(ctx, cf.dialect.grantGlobalStatement(replicationPrivileges, username, host)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", username, host))
+
+	return nil
+}
+
+func resourceReplicationUserRead(_ context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceReplicationUserRead()")
+	defer log.Println("[DEBUG] EXIT resourceReplicationUserRead()")
+
+	username := d.Get(replicationUsernameKey).(string)
+	host := d.Get(replicationHostKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	userPresent, err := userExists(db, username, host)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !userPresent {
+		log.Printf("[DEBUG] replication user %s@%s no longer exists", username, host)
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceReplicationUserUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceReplicationUserUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceReplicationUserUpdate()")
+
+	username := d.Get(replicationUsernameKey).(string)
+	host := d.Get(replicationHostKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	var clauses []string
+	if d.HasChange(replicationPasswordKey) {
+		clauses = append(clauses, fmt.Sprintf("IDENTIFIED BY %s", quotedString(d.Get(replicationPasswordKey).(string))))
+	}
+	if d.HasChanges(replicationRequireX509Key, replicationSSLSubjectKey, replicationSSLIssuerKey, replicationSSLCipherKey) {
+		clauses = append(clauses, replicationTLSRequirement(d).clause())
+	}
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, cf.dialect.alterUserStatement(username, host, strings.Join(clauses, " "))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceReplicationUserDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceReplicationUserDelete()")
+	defer log.Println("[DEBUG] EXIT resourceReplicationUserDelete()")
+
+	deleteBindingMutex.Lock()
+	defer deleteBindingMutex.Unlock()
+
+	username := d.Get(replicationUsernameKey).(string)
+	host := d.Get(replicationHostKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	log.Println("[DEBUG] dropping replication user")
+	if _, err := db.ExecContext(ctx, cf.dialect.dropUserStatement(username, host)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// replicationTLSRequirement always requires TLS, and a client certificate when asked to
+func replicationTLSRequirement(d *schema.ResourceData) tlsRequirement {
+	return tlsRequirement{
+		ssl:     true,
+		x509:    d.Get(replicationRequireX509Key).(bool),
+		subject: d.Get(replicationSSLSubjectKey).(string),
+		issuer:  d.Get(replicationSSLIssuerKey).(string),
+		cipher:  d.Get(replicationSSLCipherKey).(string),
+	}
+}
+
+// resourceReplicationUserCustomizeDiff checks that the admin user can create or alter the replication user.
+// MariaDB shows REPLICATION CLIENT as BINLOG MONITOR since 10.5.
+func resourceReplicationUserCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok {
+		return nil
+	}
+	username := d.Get(replicationUsernameKey).(string)
+
+	if d.Id() == "" {
+		return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("create replication user %q", username), []requiredPrivilege{
+			{privilege: "CREATE USER"},
+			{privilege: "REPLICATION SLAVE", grantOption: true},
+			{privilege: "REPLICATION CLIENT", grantOption: true, alternatives: []requiredPrivilege{{privilege: "BINLOG MONITOR", grantOption: true}}},
+		})
+	}
+	return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("update replication user %q", username), []requiredPrivilege{
+		{privilege: "CREATE USER"},
+	})
+}
@@ -0,0 +1,77 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Replication user resource", func() {
+	It("creates a user with global replication privileges", func() {
+		const username = "replicator"
+		resourceName := fmt.Sprintf("%s.replicator", csbmysql.ResourceReplicationUserNameKey)
+		replicationUserDefinition := func(requireX509 bool) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "replicator" {
+  username     = "%s"
+  password     = "replicator-password"
+  require_x509 = %t
+}
+`, csbmysql.ResourceReplicationUserNameKey, username, requireX509))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkUserIsDestroyed(username, true),
+			Steps: []resource.TestStep{
+				{
+					Config: replicationUserDefinition(false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "host", "%"),
+						checkReplicationUser(username, "ANY"),
+					),
+				},
+				{
+					Config: replicationUserDefinition(true),
+					Check:  checkReplicationUser(username, "X509"),
+				},
+			},
+		})
+	})
+})
+
+func checkReplicationUser(username, expectedSSLType string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var sslType string
+		Expect(db.QueryRow("SELECT ssl_type FROM mysql.user WHERE user = ? AND host = '%'", username).Scan(&sslType)).To(Succeed())
+		Expect(sslType).To(Equal(expectedSSLType))
+
+		rows, err := db.Query(fmt.Sprintf("SHOW GRANTS FOR '%s'@'%%'", username))
+		Expect(err).NotTo(HaveOccurred())
+		defer func(rows *sql.Rows) {
+			_ = rows.Close()
+		}(rows)
+
+		var grants []string
+		for rows.Next() {
+			var g string
+			Expect(rows.Scan(&g)).To(Succeed())
+			grants = append(grants, g)
+		}
+		Expect(grants).To(ConsistOf(MatchRegexp("^GRANT REPLICATION SLAVE, REPLICATION CLIENT ON \\*\\.\\* TO")))
+		return nil
+	}
+}
@@ -0,0 +1,280 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	resourceGroupNameKey           = "name"
+	resourceGroupVCPUsKey          = "vcpus"
+	resourceGroupThreadPriorityKey = "thread_priority"
+	resourceGroupEnabledKey        = "enabled"
+
+	// resourceGroupTypeUser is the only type that can be created, SYSTEM groups are for background threads
+	resourceGroupTypeUser = "USER"
+)
+
+var vcpuRangePattern = regexp.MustCompile(`^\d+(-\d+)?$`)
+
+func ResourceResourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceResourceGroupSchema,
+		CreateContext: resourceResourceGroupCreate,
+		ReadContext:   resourceResourceGroupRead,
+		UpdateContext: resourceResourceGroupUpdate,
+		DeleteContext: resourceResourceGroupDelete,
+		CustomizeDiff: resourceResourceGroupCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "A USER resource group limiting the CPUs and priority of the threads assigned to it. " +
+			"Only available on self-managed MySQL 8, and thread priorities need the CAP_SYS_NICE capability on Linux.",
+	}
+}
+
+var resourceResourceGroupSchema = map[string]*schema.Schema{
+	resourceGroupNameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	resourceGroupVCPUsKey: {
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringMatch(vcpuRangePattern, "must be a CPU number or a range such as \"0-3\""),
+		},
+		Description: "CPU numbers or ranges, for example [\"0-3\", \"6\"]. Defaults to all CPUs.",
+	},
+	resourceGroupThreadPriorityKey: {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntBetween(0, 19),
+		Description:  "Nice value of the threads, from 0 to 19 where higher is a lower priority.",
+	},
+	resourceGroupEnabledKey: {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	},
+}
+
+func resourceResourceGroupCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceResourceGroupCreate()")
+	defer log.Println("[DEBUG] EXIT resourceResourceGroupCreate()")
+
+	name := d.Get(resourceGroupNameKey).(string)
+
+	cf := m.(connectionFactory)
+	if err := requireFeature(cf.dialect, featureResourceGroups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	statement := fmt.Sprintf("CREATE RESOURCE GROUP %s TYPE = %s%s", quotedIdentifier(name), resourceGroupTypeUser, resourceGroupOptions(d))
+	if _, err := db.ExecContext(ctx, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating resource group %q: %w", name, err))
+	}
+
+	d.SetId(name)
+
+	return resourceResourceGroupRead(ctx, d, m)
+}
+
+func resourceResourceGroupRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceResourceGroupRead()")
+	defer log.Println("[DEBUG] EXIT resourceResourceGroupRead()")
+
+	name := d.Id()
+
+	cf := m.(connectionFactory)
+	if err := requireFeature(cf.dialect, featureResourceGroups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	var (
+		groupType, vcpus string
+		enabled          bool
+		priority         int
+	)
+	err = db.QueryRowContext(ctx,
+		"SELECT RESOURCE_GROUP_TYPE, RESOURCE_GROUP_ENABLED, VCPU_IDS, THREAD_PRIORITY FROM information_schema.RESOURCE_GROUPS WHERE RESOURCE_GROUP_NAME = ?",
+		name,
+	).Scan(&groupType, &enabled, &vcpus, &priority)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] resource group %s no longer exists", name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading resource group %q: %w", name, err))
+	}
+	if groupType != resourceGroupTypeUser {
+		return diag.Errorf("resource group %q is a %s group, only USER groups can be managed", name, groupType)
+	}
+
+	// The server collapses the CPUs into ranges, so the configured list is kept when it has the same CPUs
+	readVCPUs := strings.Split(vcpus, ",")
+	var configuredVCPUs []string
+	for _, v := range d.Get(resourceGroupVCPUsKey).([]any) {
+		configuredVCPUs = append(configuredVCPUs, v.(string))
+	}
+	if sameVCPUs(configuredVCPUs, readVCPUs) {
+		readVCPUs = configuredVCPUs
+	}
+
+	values := map[string]any{
+		resourceGroupNameKey:           name,
+		resourceGroupVCPUsKey:          readVCPUs,
+		resourceGroupThreadPriorityKey: priority,
+		resourceGroupEnabledKey:        enabled,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceResourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceResourceGroupUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceResourceGroupUpdate()")
+
+	name := d.Get(resourceGroupNameKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER RESOURCE GROUP %s%s", quotedIdentifier(name), resourceGroupOptions(d))); err != nil {
+		return diag.FromErr(fmt.Errorf("error altering resource group %q: %w", name, err))
+	}
+
+	return resourceResourceGroupRead(ctx, d, m)
+}
+
+// resourceResourceGroupDelete uses FORCE, which moves any threads still in the group to the default group
+func resourceResourceGroupDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceResourceGroupDelete()")
+	defer log.Println("[DEBUG] EXIT resourceResourceGroupDelete()")
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP RESOURCE GROUP %s FORCE", quotedIdentifier(d.Id()))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceGroupOptions returns the clauses shared by CREATE and ALTER RESOURCE GROUP
+func resourceGroupOptions(d *schema.ResourceData) string {
+	var b strings.Builder
+	var vcpus []string
+	for _, v := range d.Get(resourceGroupVCPUsKey).([]any) {
+		vcpus = append(vcpus, v.(string))
+	}
+	if len(vcpus) > 0 {
+		fmt.Fprintf(&b, " VCPU = %s", strings.Join(vcpus, ","))
+	}
+	fmt.Fprintf(&b, " THREAD_PRIORITY = %d", d.Get(resourceGroupThreadPriorityKey).(int))
+	if d.Get(resourceGroupEnabledKey).(bool) {
+		b.WriteString(" ENABLE")
+	} else {
+		b.WriteString(" DISABLE")
+	}
+	return b.String()
+}
+
+// sameVCPUs reports whether two lists of CPU numbers and ranges, such as ["0", "1", "2", "3"] and ["0-3"], have the same CPUs
+func sameVCPUs(a, b []string) bool {
+	expandedA, err := expandVCPUs(a)
+	if err != nil {
+		return false
+	}
+	expandedB, err := expandVCPUs(b)
+	if err != nil {
+		return false
+	}
+	return maps.Equal(expandedA, expandedB)
+}
+
+func expandVCPUs(vcpus []string) (map[int]struct{}, error) {
+	expanded := make(map[int]struct{})
+	for _, v := range vcpus {
+		first, last, isRange := strings.Cut(strings.TrimSpace(v), "-")
+		if !isRange {
+			last = first
+		}
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU range %q: %w", v, err)
+		}
+		end, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU range %q: %w", v, err)
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			expanded[cpu] = struct{}{}
+		}
+	}
+	return expanded, nil
+}
+
+// resourceResourceGroupCustomizeDiff checks that the admin user can create or alter the resource group
+func resourceResourceGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok || (d.Id() != "" && !d.HasChanges(resourceGroupVCPUsKey, resourceGroupThreadPriorityKey, resourceGroupEnabledKey)) {
+		return nil
+	}
+
+	return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("create or alter resource group %q", d.Get(resourceGroupNameKey).(string)), []requiredPrivilege{
+		{privilege: "RESOURCE_GROUP_ADMIN"},
+	})
+}
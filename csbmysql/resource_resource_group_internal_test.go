@@ -0,0 +1,19 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resource group", func() {
+	DescribeTable("sameVCPUs",
+		func(configured, read []string, expected bool) {
+			Expect(sameVCPUs(configured, read)).To(Equal(expected))
+		},
+		Entry("collapsed into a range", []string{"0", "1", "2", "3"}, []string{"0-3"}, true),
+		Entry("in another order", []string{"6", "0-3"}, []string{"0-3", "6"}, true),
+		Entry("overlapping ranges", []string{"0-2", "1-3"}, []string{"0-3"}, true),
+		Entry("different CPUs", []string{"0", "1"}, []string{"0-3"}, false),
+		Entry("nothing configured", nil, []string{"0-3"}, false),
+	)
+})
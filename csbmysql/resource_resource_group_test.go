@@ -0,0 +1,164 @@
+package csbmysql_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Resource group resource", func() {
+	BeforeEach(skipOnMySQL57)
+
+	It("creates a resource group that binding user sessions can join", func() {
+		const (
+			username = "analytics-user"
+			password = "analytics-password"
+		)
+		groupName := fmt.Sprintf("%s.analytics", csbmysql.ResourceResourceGroupNameKey)
+		bindingName := fmt.Sprintf("%s.analytics", csbmysql.ResourceNameKey)
+		resourceGroupDefinition := func(priority int, enabled bool, resourceGroup string) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%[1]s" "analytics" {
+  name            = "analytics"
+  vcpus           = ["0"]
+  thread_priority = %[3]d
+  enabled         = %[4]t
+}
+
+resource "%[2]s" "analytics" {
+  username       = "%[5]s"
+  password       = "%[6]s"
+  read_only      = true
+  resource_group = %[7]s
+}
+`, csbmysql.ResourceResourceGroupNameKey, csbmysql.ResourceNameKey, priority, enabled, username, password, resourceGroup))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkResourceGroupIsDestroyed("analytics"),
+			Steps: []resource.TestStep{
+				{
+					Config: resourceGroupDefinition(10, true, `""`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(bindingName, "init_command", ""),
+						checkResourceGroupUser(username, false),
+					),
+				},
+				{
+					Config: resourceGroupDefinition(10, true, csbmysql.ResourceResourceGroupNameKey+".analytics.name"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(groupName, "vcpus.0", "0"),
+						resource.TestCheckResourceAttr(bindingName, "init_command", "SET RESOURCE GROUP `analytics`"),
+						checkResourceGroup("analytics", true),
+						checkResourceGroupUser(username, true),
+						checkSessionJoinsResourceGroup(username, password, "analytics"),
+					),
+				},
+				{
+					Config: resourceGroupDefinition(10, false, csbmysql.ResourceResourceGroupNameKey+".analytics.name"),
+					Check:  checkResourceGroup("analytics", false),
+				},
+				{
+					Config: resourceGroupDefinition(10, false, `""`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(bindingName, "init_command", ""),
+						checkResourceGroupUser(username, false),
+					),
+				},
+				{
+					ResourceName:      groupName,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+})
+
+func checkResourceGroup(name string, enabled bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var (
+			groupType     string
+			actualEnabled bool
+		)
+		Expect(db.QueryRow(
+			"SELECT RESOURCE_GROUP_TYPE, RESOURCE_GROUP_ENABLED FROM information_schema.RESOURCE_GROUPS WHERE RESOURCE_GROUP_NAME = ?", name,
+		).Scan(&groupType, &actualEnabled)).To(Succeed())
+		Expect(groupType).To(Equal("USER"))
+		Expect(actualEnabled).To(Equal(enabled))
+		return nil
+	}
+}
+
+func checkResourceGroupUser(username string, expected bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var count int
+		Expect(db.QueryRow(
+			"SELECT COUNT(*) FROM mysql.global_grants WHERE USER = ? AND HOST = '%' AND PRIV = 'RESOURCE_GROUP_USER'", username,
+		).Scan(&count)).To(Succeed())
+		Expect(count > 0).To(Equal(expected))
+		return nil
+	}
+}
+
+func checkSessionJoinsResourceGroup(username, password, group string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=skip-verify", username, password, dbHost, port, database))
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		conn, err := db.Conn(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		defer func(conn *sql.Conn) {
+			_ = conn.Close()
+		}(conn)
+
+		_, err = conn.ExecContext(context.Background(), fmt.Sprintf("SET RESOURCE GROUP `%s`", group))
+		Expect(err).NotTo(HaveOccurred())
+
+		var actual string
+		Expect(conn.QueryRowContext(context.Background(),
+			"SELECT RESOURCE_GROUP FROM performance_schema.threads WHERE PROCESSLIST_ID = CONNECTION_ID()",
+		).Scan(&actual)).To(Succeed())
+		Expect(actual).To(Equal(group))
+		return nil
+	}
+}
+
+func checkResourceGroupIsDestroyed(name string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var count int
+		Expect(db.QueryRow("SELECT COUNT(*) FROM information_schema.RESOURCE_GROUPS WHERE RESOURCE_GROUP_NAME = ?", name).Scan(&count)).To(Succeed())
+		Expect(count).To(BeZero())
+		return nil
+	}
+}
@@ -0,0 +1,441 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	routineDatabaseKey      = "database"
+	routineNameKey          = "name"
+	routineTypeKey          = "type"
+	routineParametersKey    = "parameters"
+	routineParamNameKey     = "name"
+	routineParamTypeKey     = "type"
+	routineParamModeKey     = "mode"
+	routineReturnsKey       = "returns"
+	routineBodyKey          = "body"
+	routineDeterministicKey = "deterministic"
+	routineSQLSecurityKey   = "sql_security"
+	routineDefinerKey       = "definer"
+	routineCommentKey       = "comment"
+
+	routineTypeProcedure = "PROCEDURE"
+	routineTypeFunction  = "FUNCTION"
+
+	sqlSecurityDefiner = "DEFINER"
+	sqlSecurityInvoker = "INVOKER"
+)
+
+func ResourceRoutine() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceRoutineSchema,
+		CreateContext: resourceRoutineCreate,
+		ReadContext:   resourceRoutineRead,
+		UpdateContext: resourceRoutineUpdate,
+		DeleteContext: resourceRoutineDelete,
+		CustomizeDiff: resourceRoutineCustomizeDiff,
+		Description: "A stored procedure or function. Changing the body, parameters or definer recreates the routine. " +
+			"When binary logging is enabled, functions must be deterministic unless log_bin_trust_function_creators is set.",
+	}
+}
+
+var resourceRoutineSchema = map[string]*schema.Schema{
+	routineDatabaseKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "Database to create the routine in. Defaults to the database of the provider.",
+	},
+	routineNameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	routineTypeKey: {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{routineTypeProcedure, routineTypeFunction}, false),
+	},
+	routineParametersKey: {
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				routineParamNameKey: {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+				routineParamTypeKey: {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: "Data type of the parameter, for example \"INT\" or \"VARCHAR(255)\".",
+				},
+				routineParamModeKey: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      "IN",
+					ValidateFunc: validation.StringInSlice([]string{"IN", "OUT", "INOUT"}, false),
+					Description:  "Only procedures may have OUT and INOUT parameters.",
+				},
+			},
+		},
+	},
+	routineReturnsKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Return type of a function. Required for functions and not allowed for procedures.",
+	},
+	routineBodyKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+			return strings.TrimSpace(oldValue) == strings.TrimSpace(newValue)
+		},
+		Description: "Routine body, such as a single statement or a BEGIN ... END block. No DELIMITER is needed.",
+	},
+	routineDeterministicKey: {
+		Type:     schema.TypeBool,
+		Optional: true,
+		ForceNew: true,
+		Default:  false,
+	},
+	routineSQLSecurityKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      sqlSecurityDefiner,
+		ValidateFunc: validation.StringInSlice([]string{sqlSecurityDefiner, sqlSecurityInvoker}, false),
+	},
+	routineDefinerKey: {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: suppressEquivalentAccount,
+		Description:      "Account the routine runs as with DEFINER security, as user@host. Defaults to the admin user of the provider.",
+	},
+	routineCommentKey: {
+		Type:     schema.TypeString,
+		Optional: true,
+	},
+}
+
+func resourceRoutineCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceRoutineCreate()")
+	defer log.Println("[DEBUG] EXIT resourceRoutineCreate()")
+
+	cf := m.(connectionFactory)
+
+	database := d.Get(routineDatabaseKey).(string)
+	if database == "" {
+		database = cf.database
+	}
+	name := d.Get(routineNameKey).(string)
+	routineType := d.Get(routineTypeKey).(string)
+
+	statement, err := createRoutineStatement(d, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating %s %s.%s: %w", strings.ToLower(routineType), database, name, err))
+	}
+
+	if err := d.Set(routineDatabaseKey, database); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s.%s", routineType, database, name))
+
+	return resourceRoutineRead(ctx, d, m)
+}
+
+func resourceRoutineRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceRoutineRead()")
+	defer log.Println("[DEBUG] EXIT resourceRoutineRead()")
+
+	database := d.Get(routineDatabaseKey).(string)
+	name := d.Get(routineNameKey).(string)
+	routineType := d.Get(routineTypeKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	var (
+		body                                    sql.NullString
+		deterministic, security, definer, notes string
+	)
+	err = db.QueryRowContext(ctx, `
+SELECT ROUTINE_DEFINITION, IS_DETERMINISTIC, SECURITY_TYPE, DEFINER, ROUTINE_COMMENT
+FROM information_schema.ROUTINES
+WHERE ROUTINE_SCHEMA = ? AND ROUTINE_NAME = ? AND ROUTINE_TYPE = ?`, database, name, routineType).Scan(&body, &deterministic, &security, &definer, &notes)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] %s %s.%s no longer exists", strings.ToLower(routineType), database, name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading %s %s.%s: %w", strings.ToLower(routineType), database, name, err))
+	}
+
+	parameters, err := readRoutineParameters(ctx, db, database, name, routineType, d.Get(routineParametersKey).([]any))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading parameters of %s %s.%s: %w", strings.ToLower(routineType), database, name, err))
+	}
+
+	values := map[string]any{
+		routineParametersKey:    parameters,
+		routineDeterministicKey: isEnabled(deterministic),
+		routineSQLSecurityKey:   security,
+		routineDefinerKey:       definer,
+		routineCommentKey:       notes,
+	}
+	// The definition is only visible to the definer or to users with SHOW_ROUTINE or SELECT on mysql.proc
+	if body.Valid {
+		values[routineBodyKey] = strings.TrimSpace(body.String)
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// readRoutineParameters reads the parameters of a routine in order. The server reads data types back
+// in its own form, like int for INTEGER, so a configured type is kept when it is equivalent.
+func readRoutineParameters(ctx context.Context, db *sql.DB, database, name, routineType string, configured []any) ([]any, error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT PARAMETER_NAME, PARAMETER_MODE, DTD_IDENTIFIER
+FROM information_schema.PARAMETERS
+WHERE SPECIFIC_SCHEMA = ? AND SPECIFIC_NAME = ? AND ROUTINE_TYPE = ? AND ORDINAL_POSITION > 0
+ORDER BY ORDINAL_POSITION`, database, name, routineType)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	parameters := make([]any, 0)
+	for rows.Next() {
+		var (
+			parameterName, dataType string
+			mode                    sql.NullString
+		)
+		if err := rows.Scan(&parameterName, &mode, &dataType); err != nil {
+			return nil, err
+		}
+
+		// function parameters have no mode
+		if !mode.Valid {
+			mode.String = "IN"
+		}
+		if i := len(parameters); i < len(configured) && configured[i] != nil {
+			if configuredType := configured[i].(map[string]any)[routineParamTypeKey].(string); equivalentDataTypes(configuredType, dataType) {
+				dataType = configuredType
+			}
+		}
+
+		parameters = append(parameters, map[string]any{
+			routineParamNameKey: parameterName,
+			routineParamTypeKey: dataType,
+			routineParamModeKey: mode.String,
+		})
+	}
+
+	return parameters, rows.Err()
+}
+
+var integerDisplayWidthPattern = regexp.MustCompile(`^((tiny|small|medium|big)?int)\(\d+\)`)
+
+// equivalentDataTypes compares a configured data type with the one the server reads back, which is lower case,
+// has no display width for integers since MySQL 8.0.19, and names a character set even when none was configured
+func equivalentDataTypes(configured, readBack string) bool {
+	normalize := func(dataType string) string {
+		dataType = strings.Join(strings.Fields(strings.ToLower(dataType)), " ")
+		dataType = strings.ReplaceAll(dataType, "character set", "charset")
+		switch {
+		case dataType == "bool" || dataType == "boolean":
+			return "tinyint(1)"
+		case strings.HasPrefix(dataType, "integer"):
+			dataType = "int" + strings.TrimPrefix(dataType, "integer")
+		}
+		if strings.HasPrefix(dataType, "tinyint(1)") {
+			return dataType
+		}
+		return integerDisplayWidthPattern.ReplaceAllString(dataType, "$1")
+	}
+
+	configured, readBack = normalize(configured), normalize(readBack)
+	return configured == readBack || strings.HasPrefix(readBack, configured+" charset ")
+}
+
+// resourceRoutineUpdate changes the characteristics that ALTER PROCEDURE and ALTER FUNCTION
+// allow to change. Everything else forces a new routine.
+func resourceRoutineUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceRoutineUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceRoutineUpdate()")
+
+	database := d.Get(routineDatabaseKey).(string)
+	name := d.Get(routineNameKey).(string)
+	routineType := d.Get(routineTypeKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	statement := fmt.Sprintf("ALTER %s %s.%s SQL SECURITY %s COMMENT %s",
+		routineType,
+		quotedIdentifier(database),
+		quotedIdentifier(name),
+		d.Get(routineSQLSecurityKey).(string),
+		quotedString(d.Get(routineCommentKey).(string)),
+	)
+	if _, err := db.ExecContext(ctx, statement); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceRoutineRead(ctx, d, m)
+}
+
+func resourceRoutineDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceRoutineDelete()")
+	defer log.Println("[DEBUG] EXIT resourceRoutineDelete()")
+
+	database := d.Get(routineDatabaseKey).(string)
+	name := d.Get(routineNameKey).(string)
+	routineType := d.Get(routineTypeKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP %s IF EXISTS %s.%s", routineType, quotedIdentifier(database), quotedIdentifier(name))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// createRoutineStatement builds CREATE PROCEDURE or CREATE FUNCTION from the resource configuration
+func createRoutineStatement(d *schema.ResourceData, database string) (string, error) {
+	name := d.Get(routineNameKey).(string)
+	routineType := d.Get(routineTypeKey).(string)
+	returns := d.Get(routineReturnsKey).(string)
+
+	switch {
+	case routineType == routineTypeFunction && returns == "":
+		return "", fmt.Errorf("function %s.%s must have a return type", database, name)
+	case routineType == routineTypeProcedure && returns != "":
+		return "", fmt.Errorf("procedure %s.%s cannot have a return type", database, name)
+	}
+
+	var parameters []string
+	for _, p := range d.Get(routineParametersKey).([]any) {
+		parameter := p.(map[string]any)
+		mode := parameter[routineParamModeKey].(string)
+		declaration := fmt.Sprintf("%s %s", quotedIdentifier(parameter[routineParamNameKey].(string)), parameter[routineParamTypeKey].(string))
+		switch {
+		case routineType == routineTypeProcedure:
+			declaration = fmt.Sprintf("%s %s", mode, declaration)
+		case mode != "IN":
+			return "", fmt.Errorf("function %s.%s can only have IN parameters", database, name)
+		}
+		parameters = append(parameters, declaration)
+	}
+
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if definer := d.Get(routineDefinerKey).(string); definer != "" {
+		fmt.Fprintf(&b, "DEFINER = %s ", quotedAccount(definer))
+	}
+	fmt.Fprintf(&b, "%s %s.%s(%s)", routineType, quotedIdentifier(database), quotedIdentifier(name), strings.Join(parameters, ", "))
+	if routineType == routineTypeFunction {
+		fmt.Fprintf(&b, " RETURNS %s", returns)
+	}
+	if d.Get(routineDeterministicKey).(bool) {
+		b.WriteString(" DETERMINISTIC")
+	} else {
+		b.WriteString(" NOT DETERMINISTIC")
+	}
+	fmt.Fprintf(&b, " SQL SECURITY %s", d.Get(routineSQLSecurityKey).(string))
+	if comment := d.Get(routineCommentKey).(string); comment != "" {
+		fmt.Fprintf(&b, " COMMENT %s", quotedString(comment))
+	}
+	fmt.Fprintf(&b, "\n%s", strings.TrimSpace(d.Get(routineBodyKey).(string)))
+
+	return b.String(), nil
+}
+
+// resourceRoutineCustomizeDiff checks that the admin user can create the routine, or alter it
+func resourceRoutineCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	cf, ok := m.(connectionFactory)
+	if !ok {
+		return nil
+	}
+
+	database := d.Get(routineDatabaseKey).(string)
+	if database == "" {
+		database = cf.database
+	}
+	name := d.Get(routineNameKey).(string)
+
+	switch {
+	case d.Id() == "":
+		return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("create routine %s.%s", database, name), []requiredPrivilege{
+			{privilege: "CREATE ROUTINE", database: database},
+		})
+	case d.HasChanges(routineSQLSecurityKey, routineCommentKey):
+		return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("alter routine %s.%s", database, name), []requiredPrivilege{
+			{privilege: "ALTER ROUTINE", database: database},
+		})
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,21 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Routine", func() {
+	DescribeTable("equivalentDataTypes",
+		func(configured, readBack string, equivalent bool) {
+			Expect(equivalentDataTypes(configured, readBack)).To(Equal(equivalent))
+		},
+		Entry("same type in another case", "DECIMAL(10,2)", "decimal(10,2)", true),
+		Entry("integer with a display width", "INT", "int(11)", true),
+		Entry("integer alias", "INTEGER UNSIGNED", "int unsigned", true),
+		Entry("boolean", "BOOLEAN", "tinyint(1)", true),
+		Entry("character set added by the server", "VARCHAR(255)", "varchar(255) CHARSET utf8mb4", true),
+		Entry("different length", "VARCHAR(255)", "varchar(100)", false),
+		Entry("different type", "INT", "bigint", false),
+	)
+})
@@ -0,0 +1,129 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("Routine resource", func() {
+	It("creates stored functions and procedures", func() {
+		functionName := fmt.Sprintf("%s.add_tax", csbmysql.ResourceRoutineNameKey)
+		routineDefinition := func(sqlSecurity string) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%[1]s" "add_tax" {
+  name          = "add_tax"
+  type          = "FUNCTION"
+  returns       = "DECIMAL(10,2)"
+  deterministic = true
+  sql_security  = "%[2]s"
+  body          = "RETURN amount * 1.2"
+
+  parameters {
+    name = "amount"
+    type = "DECIMAL(10,2)"
+  }
+}
+
+resource "%[1]s" "count_tasks" {
+  name = "count_tasks"
+  type = "PROCEDURE"
+  body = <<-EOT
+    BEGIN
+      SELECT COUNT(*) INTO total FROM previous_table;
+    END
+  EOT
+
+  parameters {
+    name = "total"
+    type = "INT"
+    mode = "OUT"
+  }
+}
+`, csbmysql.ResourceRoutineNameKey, sqlSecurity))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkRoutineIsDestroyed("add_tax", "count_tasks"),
+			Steps: []resource.TestStep{
+				{
+					Config: routineDefinition("DEFINER"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(functionName, "database", database),
+						resource.TestCheckResourceAttr(functionName, "definer", "root@%"),
+						resource.TestCheckResourceAttr(functionName, "parameters.0.name", "amount"),
+						resource.TestCheckResourceAttr(functionName, "parameters.0.type", "DECIMAL(10,2)"),
+						resource.TestCheckResourceAttr(fmt.Sprintf("%s.count_tasks", csbmysql.ResourceRoutineNameKey), "parameters.0.mode", "OUT"),
+						checkRoutine("add_tax", "FUNCTION", "YES", "DEFINER"),
+						checkRoutine("count_tasks", "PROCEDURE", "NO", "DEFINER"),
+						checkAddTax(),
+					),
+				},
+				{
+					Config: routineDefinition("INVOKER"),
+					Check:  checkRoutine("add_tax", "FUNCTION", "YES", "INVOKER"),
+				},
+			},
+		})
+	})
+})
+
+func checkRoutine(name, routineType, deterministic, sqlSecurity string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var actualType, actualDeterministic, actualSecurity string
+		Expect(db.QueryRow(
+			"SELECT ROUTINE_TYPE, IS_DETERMINISTIC, SECURITY_TYPE FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = ? AND ROUTINE_NAME = ?",
+			database, name,
+		).Scan(&actualType, &actualDeterministic, &actualSecurity)).To(Succeed())
+		Expect(actualType).To(Equal(routineType))
+		Expect(actualDeterministic).To(Equal(deterministic))
+		Expect(actualSecurity).To(Equal(sqlSecurity))
+		return nil
+	}
+}
+
+func checkAddTax() resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var result string
+		Expect(db.QueryRow(fmt.Sprintf("SELECT `%s`.add_tax(10)", database)).Scan(&result)).To(Succeed())
+		Expect(result).To(Equal("12.00"))
+		return nil
+	}
+}
+
+func checkRoutineIsDestroyed(names ...string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		for _, name := range names {
+			var count int
+			Expect(db.QueryRow("SELECT COUNT(*) FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = ? AND ROUTINE_NAME = ?", database, name).Scan(&count)).To(Succeed())
+			Expect(count).To(BeZero(), "routine %s still exists", name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,376 @@
+package csbmysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	viewDatabaseKey       = "database"
+	viewNameKey           = "name"
+	viewSelectKey         = "select"
+	viewAlgorithmKey      = "algorithm"
+	viewDefinerKey        = "definer"
+	viewSQLSecurityKey    = "sql_security"
+	viewCheckOptionKey    = "check_option"
+	viewSelectGranteesKey = "select_grantees"
+	viewDefinitionKey     = "definition"
+
+	viewCheckOptionNone = "NONE"
+
+	// errorNonexistingTableGrant is ER_NONEXISTING_TABLE_GRANT, returned when revoking a privilege that was not granted
+	errorNonexistingTableGrant = 1147
+)
+
+var (
+	viewAlgorithmPattern = regexp.MustCompile(`ALGORITHM=(\w+)`)
+	granteePattern       = regexp.MustCompile(`^'(.*)'@'(.*)'$`)
+)
+
+func ResourceView() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceViewSchema,
+		CreateContext: resourceViewCreate,
+		ReadContext:   resourceViewRead,
+		UpdateContext: resourceViewUpdate,
+		DeleteContext: resourceViewDelete,
+		CustomizeDiff: resourceViewCustomizeDiff,
+		Description: "A view created with CREATE OR REPLACE VIEW, optionally readable by binding users " +
+			"that have no access to the underlying tables",
+	}
+}
+
+var resourceViewSchema = map[string]*schema.Schema{
+	viewDatabaseKey: {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "Database to create the view in. Defaults to the database of the provider.",
+	},
+	viewNameKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	viewSelectKey: {
+		Type:     schema.TypeString,
+		Required: true,
+		DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+			return strings.Join(strings.Fields(oldValue), " ") == strings.Join(strings.Fields(newValue), " ")
+		},
+		Description: "SELECT statement defining the view. The server stores it in a rewritten form, exposed as definition. " +
+			"When the view is changed outside Terraform, select is read back as that definition, so that the view is replaced.",
+	},
+	viewAlgorithmKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "UNDEFINED",
+		ValidateFunc: validation.StringInSlice([]string{"UNDEFINED", "MERGE", "TEMPTABLE"}, false),
+	},
+	viewDefinerKey: {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		DiffSuppressFunc: suppressEquivalentAccount,
+		Description:      "Account whose privileges are used with DEFINER security, as user@host. Defaults to the admin user of the provider.",
+	},
+	viewSQLSecurityKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      sqlSecurityDefiner,
+		ValidateFunc: validation.StringInSlice([]string{sqlSecurityDefiner, sqlSecurityInvoker}, false),
+	},
+	viewCheckOptionKey: {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      viewCheckOptionNone,
+		ValidateFunc: validation.StringInSlice([]string{viewCheckOptionNone, "CASCADED", "LOCAL"}, false),
+		Description:  "WITH CHECK OPTION for updatable views, one of \"NONE\", \"CASCADED\" or \"LOCAL\".",
+	},
+	viewSelectGranteesKey: {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: "Users connecting from any host, such as binding users, that are granted SELECT on the view. " +
+			"Combined with views_only on csbmysql_binding_user, this limits read-only bindings to views.",
+	},
+	viewDefinitionKey: {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The SELECT statement as stored by the server.",
+	},
+}
+
+func resourceViewCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceViewCreate()")
+	defer log.Println("[DEBUG] EXIT resourceViewCreate()")
+
+	cf := m.(connectionFactory)
+
+	database := d.Get(viewDatabaseKey).(string)
+	if database == "" {
+		database = cf.database
+	}
+	name := d.Get(viewNameKey).(string)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if _, err := db.ExecContext(ctx, createViewStatement(d, database)); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating view %s.%s: %w", database, name, err))
+	}
+
+	if err := d.Set(viewDatabaseKey, database); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s.%s", database, name))
+
+	grantees := d.Get(viewSelectGranteesKey).(*schema.Set).List()
+	if err := updateViewGrantees(ctx, db, cf.dialect, database, name, nil, grantees); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceViewRead(ctx, d, m)
+}
+
+func resourceViewRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceViewRead()")
+	defer log.Println("[DEBUG] EXIT resourceViewRead()")
+
+	database := d.Get(viewDatabaseKey).(string)
+	name := d.Get(viewNameKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	var definition, checkOption, definer, security string
+	err = db.QueryRowContext(ctx,
+		"SELECT VIEW_DEFINITION, CHECK_OPTION, DEFINER, SECURITY_TYPE FROM information_schema.VIEWS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?",
+		database, name,
+	).Scan(&definition, &checkOption, &definer, &security)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Printf("[DEBUG] view %s.%s no longer exists", database, name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("error reading view %s.%s: %w", database, name, err))
+	}
+
+	// information_schema.VIEWS has no algorithm, it is only shown by SHOW CREATE VIEW
+	var viewName, createStatement, charset, collation string
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("SHOW CREATE VIEW %s.%s", quotedIdentifier(database), quotedIdentifier(name))).
+		Scan(&viewName, &createStatement, &charset, &collation); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading view %s.%s: %w", database, name, err))
+	}
+	algorithm := "UNDEFINED"
+	if match := viewAlgorithmPattern.FindStringSubmatch(createStatement); match != nil {
+		algorithm = match[1]
+	}
+
+	grantees, err := viewGrantees(ctx, db, database, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := map[string]any{
+		viewDefinitionKey:     definition,
+		viewCheckOptionKey:    checkOption,
+		viewDefinerKey:        definer,
+		viewSQLSecurityKey:    security,
+		viewAlgorithmKey:      algorithm,
+		viewSelectGranteesKey: grantees,
+	}
+	// The server rewrites the statement, for example with qualified column names, so select is only
+	// read back when the definition is no longer the one stored when the view was last replaced
+	if stored := d.Get(viewDefinitionKey).(string); stored != "" && stored != definition {
+		values[viewSelectKey] = definition
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceViewUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceViewUpdate()")
+	defer log.Println("[DEBUG] EXIT resourceViewUpdate()")
+
+	database := d.Get(viewDatabaseKey).(string)
+	name := d.Get(viewNameKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	if d.HasChanges(viewSelectKey, viewAlgorithmKey, viewDefinerKey, viewSQLSecurityKey, viewCheckOptionKey) {
+		if _, err := db.ExecContext(ctx, createViewStatement(d, database)); err != nil {
+			return diag.FromErr(fmt.Errorf("error replacing view %s.%s: %w", database, name, err))
+		}
+	}
+
+	if d.HasChange(viewSelectGranteesKey) {
+		oldValue, newValue := d.GetChange(viewSelectGranteesKey)
+		revoked := oldValue.(*schema.Set).Difference(newValue.(*schema.Set)).List()
+		granted := newValue.(*schema.Set).Difference(oldValue.(*schema.Set)).List()
+		if err := updateViewGrantees(ctx, db, cf.dialect, database, name, revoked, granted); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceViewRead(ctx, d, m)
+}
+
+func resourceViewDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] ENTRY resourceViewDelete()")
+	defer log.Println("[DEBUG] EXIT resourceViewDelete()")
+
+	database := d.Get(viewDatabaseKey).(string)
+	name := d.Get(viewNameKey).(string)
+
+	cf := m.(connectionFactory)
+
+	db, err := cf.ConnectAsAdmin()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func(db *sql.DB) {
+		_ = db.Close()
+	}(db)
+
+	// Table level privileges outlive the view, and would apply to a later view with the same name
+	grantees := d.Get(viewSelectGranteesKey).(*schema.Set).List()
+	if err := updateViewGrantees(ctx, db, cf.dialect, database, name, grantees, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP VIEW IF EXISTS %s.%s", quotedIdentifier(database), quotedIdentifier(name))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func createViewStatement(d *schema.ResourceData, database string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE OR REPLACE ALGORITHM = %s", d.Get(viewAlgorithmKey).(string))
+	if definer := d.Get(viewDefinerKey).(string); definer != "" {
+		fmt.Fprintf(&b, " DEFINER = %s", quotedAccount(definer))
+	}
+	fmt.Fprintf(&b, " SQL SECURITY %s VIEW %s.%s AS %s",
+		d.Get(viewSQLSecurityKey).(string),
+		quotedIdentifier(database),
+		quotedIdentifier(d.Get(viewNameKey).(string)),
+		strings.TrimSpace(d.Get(viewSelectKey).(string)),
+	)
+	if checkOption := d.Get(viewCheckOptionKey).(string); checkOption != viewCheckOptionNone {
+		fmt.Fprintf(&b, " WITH %s CHECK OPTION", checkOption)
+	}
+	return b.String()
+}
+
+func updateViewGrantees(ctx context.Context, db *sql.DB, dl dialect, database, name string, revoked, granted []any) error {
+	for _, username := range revoked {
+		_, err := db.ExecContext(ctx, dl.revokeObjectStatement("SELECT", database, name, username.(string), bindingUserHostAll))
+		var mysqlErr *mysql.MySQLError
+		if err != nil && !(errors.As(err, &mysqlErr) && mysqlErr.Number == errorNonexistingTableGrant) {
+			return fmt.Errorf("error revoking SELECT on view %s.%s from %q: %w", database, name, username, err)
+		}
+	}
+	for _, username := range granted {
+		if _, err := db.ExecContext(ctx, dl.grantObjectStatement("SELECT", database, name, username.(string), bindingUserHostAll)); err != nil {
+			return fmt.Errorf("error granting SELECT on view %s.%s to %q: %w", database, name, username, err)
+		}
+	}
+	return nil
+}
+
+// viewGrantees returns the users connecting from any host that have SELECT on the view
+func viewGrantees(ctx context.Context, db *sql.DB, database, name string) ([]string, error) {
+	grantees, err := queryStrings(ctx, db,
+		"SELECT GRANTEE FROM information_schema.TABLE_PRIVILEGES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND PRIVILEGE_TYPE = 'SELECT'",
+		database, name,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error reading grants on view %s.%s: %w", database, name, err)
+	}
+
+	var usernames []string
+	for _, grantee := range grantees {
+		match := granteePattern.FindStringSubmatch(grantee)
+		if match == nil || match[2] != bindingUserHostAll {
+			continue
+		}
+		usernames = append(usernames, strings.ReplaceAll(match[1], "''", "'"))
+	}
+	return usernames, nil
+}
+
+// resourceViewCustomizeDiff checks that the admin user can create or replace the view, which needs DROP
+// when the view exists, and grant SELECT on it
+func resourceViewCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	// the definition stored for the new select is only known once the view is replaced
+	if d.Id() != "" && d.HasChange(viewSelectKey) {
+		if err := d.SetNewComputed(viewDefinitionKey); err != nil {
+			return err
+		}
+	}
+
+	cf, ok := m.(connectionFactory)
+	if !ok {
+		return nil
+	}
+
+	database := d.Get(viewDatabaseKey).(string)
+	if database == "" {
+		database = cf.database
+	}
+
+	var required []requiredPrivilege
+	switch {
+	case d.Id() == "":
+		required = append(required, requiredPrivilege{privilege: "CREATE VIEW", database: database})
+	case d.HasChanges(viewSelectKey, viewAlgorithmKey, viewDefinerKey, viewSQLSecurityKey, viewCheckOptionKey):
+		required = append(required,
+			requiredPrivilege{privilege: "CREATE VIEW", database: database},
+			requiredPrivilege{privilege: "DROP", database: database},
+		)
+	}
+	if d.HasChange(viewSelectGranteesKey) {
+		required = append(required, requiredPrivilege{privilege: "SELECT", database: database, grantOption: true})
+	}
+
+	return checkAdminPrivilegesAtPlan(ctx, cf, fmt.Sprintf("create or replace view %s.%s", database, d.Get(viewNameKey).(string)), required)
+}
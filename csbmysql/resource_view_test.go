@@ -0,0 +1,138 @@
+package csbmysql_test
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
+)
+
+var _ = Describe("View resource", func() {
+	It("creates a view that a views only binding user can read", func() {
+		const (
+			username = "report-reader"
+			password = "report-password"
+		)
+		resourceName := fmt.Sprintf("%s.report", csbmysql.ResourceViewNameKey)
+		viewDefinition := func(checkOption string) string {
+			return testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%[1]s" "reader" {
+  username   = "%[3]s"
+  password   = "%[4]s"
+  read_only  = true
+  views_only = true
+}
+
+resource "%[2]s" "report" {
+  name            = "report"
+  select          = "SELECT pk, value FROM previous_table"
+  check_option    = "%[5]s"
+  select_grantees = [%[1]s.reader.username]
+}
+`, csbmysql.ResourceNameKey, csbmysql.ResourceViewNameKey, username, password, checkOption))
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			CheckDestroy:      checkViewIsDestroyed("report"),
+			Steps: []resource.TestStep{
+				{
+					Config: viewDefinition("NONE"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "database", database),
+						resource.TestCheckResourceAttr(resourceName, "sql_security", "DEFINER"),
+						resource.TestCheckResourceAttr(resourceName, "algorithm", "UNDEFINED"),
+						resource.TestCheckResourceAttr(resourceName, "select_grantees.#", "1"),
+						checkViewsOnlyAccess(username, password, "report"),
+					),
+				},
+				{
+					Config: viewDefinition("CASCADED"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "check_option", "CASCADED"),
+						checkViewsOnlyAccess(username, password, "report"),
+					),
+				},
+				{
+					PreConfig:          alterView("report", "SELECT pk FROM previous_table"),
+					Config:             viewDefinition("CASCADED"),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+	})
+
+	It("fails the plan when views_only is set without read_only", func() {
+		resource.Test(GinkgoT(), resource.TestCase{
+			IsUnitTest:        true,
+			ProviderFactories: getTestProviderFactories(initTestProvider()),
+			Steps: []resource.TestStep{{
+				Config: testGetDefinition(csbMySQLProvider + fmt.Sprintf(`
+resource "%s" "reader" {
+  username   = "report-reader"
+  password   = "report-password"
+  views_only = true
+}
+`, csbmysql.ResourceNameKey)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("views_only requires read_only"),
+			}},
+		})
+	})
+})
+
+func checkViewsOnlyAccess(username, password, view string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=skip-verify", username, password, dbHost, port, database))
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var value string
+		Expect(db.QueryRow(fmt.Sprintf("SELECT value FROM %s WHERE pk = 1", view)).Scan(&value)).To(Succeed())
+		Expect(value).To(Equal("value"))
+
+		_, err = db.Exec("SELECT value FROM previous_table")
+		Expect(err).To(MatchError(ContainSubstring("command denied")))
+		return nil
+	}
+}
+
+func checkViewIsDestroyed(name string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		var views, grants int
+		Expect(db.QueryRow("SELECT COUNT(*) FROM information_schema.VIEWS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", database, name).Scan(&views)).To(Succeed())
+		Expect(views).To(BeZero())
+		Expect(db.QueryRow("SELECT COUNT(*) FROM mysql.tables_priv WHERE Db = ? AND Table_name = ?", database, name).Scan(&grants)).To(Succeed())
+		Expect(grants).To(BeZero())
+		return nil
+	}
+}
+
+func alterView(name, selectStatement string) func() {
+	return func() {
+		db, err := sql.Open("mysql", adminUserURI)
+		Expect(err).NotTo(HaveOccurred())
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(db)
+
+		_, err = db.Exec(fmt.Sprintf("ALTER VIEW `%s`.`%s` AS %s", database, name, selectStatement))
+		Expect(err).NotTo(HaveOccurred())
+	}
+}
@@ -0,0 +1,174 @@
+package csbmysql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// certificateExpiryWarningPeriod is how long before expiry we start warning about a certificate
+const certificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// validatePEMCertificates checks that the value holds one or more PEM encoded
+// certificates that are currently valid, and warns when one of them expires soon.
+func validatePEMCertificates(v any, path cty.Path) diag.Diagnostics {
+	certs, diags := parsePEMCertificates(v.(string), path)
+	for _, cert := range certs {
+		diags = append(diags, checkCertificateDates(cert, path, time.Now())...)
+	}
+	return diags
+}
+
+// validateCACertificates checks a bundle of PEM encoded CA certificates. Bundles often keep
+// expired certificates alongside the current ones, so they only cause warnings, and the bundle
+// is rejected when none of its certificates is currently valid.
+func validateCACertificates(v any, path cty.Path) diag.Diagnostics {
+	certs, diags := parsePEMCertificates(v.(string), path)
+	if len(certs) == 0 {
+		return diags
+	}
+
+	valid := false
+	for _, cert := range certs {
+		certDiags := checkCertificateDates(cert, path, time.Now())
+		if !certDiags.HasError() {
+			valid = true
+		}
+		for _, d := range certDiags {
+			d.Severity = diag.Warning
+			diags = append(diags, d)
+		}
+	}
+
+	if !valid {
+		diags = append(diags, attributeError(path, "No valid certificate", "none of the certificates in the bundle is currently valid"))
+	}
+	return diags
+}
+
+// parsePEMCertificates returns the certificates in the value, which is empty when not set
+func parsePEMCertificates(value string, path cty.Path) ([]*x509.Certificate, diag.Diagnostics) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var certs []*x509.Certificate
+	rest := []byte(value)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, diag.Diagnostics{attributeError(path, "Invalid certificate", fmt.Sprintf("unable to parse certificate: %s", err))}
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, diag.Diagnostics{attributeError(path, "Invalid certificate", "no PEM encoded certificate found")}
+	}
+
+	return certs, nil
+}
+
+func checkCertificateDates(cert *x509.Certificate, path cty.Path, now time.Time) diag.Diagnostics {
+	subject := cert.Subject.String()
+	switch {
+	case now.Before(cert.NotBefore):
+		return diag.Diagnostics{attributeError(path, "Certificate not yet valid",
+			fmt.Sprintf("certificate %q is not valid before %s", subject, cert.NotBefore.Format(time.RFC3339)))}
+	case now.After(cert.NotAfter):
+		return diag.Diagnostics{attributeError(path, "Certificate expired",
+			fmt.Sprintf("certificate %q expired on %s", subject, cert.NotAfter.Format(time.RFC3339)))}
+	case now.Add(certificateExpiryWarningPeriod).After(cert.NotAfter):
+		return diag.Diagnostics{attributeWarning(path, "Certificate expires soon",
+			fmt.Sprintf("certificate %q expires on %s", subject, cert.NotAfter.Format(time.RFC3339)))}
+	default:
+		return nil
+	}
+}
+
+// validatePEMPrivateKey checks that the value holds a PEM encoded private key
+// in one of the formats that crypto/tls accepts.
+func validatePEMPrivateKey(v any, path cty.Path) diag.Diagnostics {
+	value := v.(string)
+	if value == "" {
+		return nil
+	}
+
+	rest := []byte(value)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return diag.Diagnostics{attributeError(path, "Invalid private key", "no PEM encoded private key found")}
+		}
+
+		if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+	}
+}
+
+// validateProviderConfig performs the checks that involve more than one attribute.
+// It runs when the provider is configured, which Terraform also does during plan.
+func validateProviderConfig(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cert := d.Get(sslCertKey).(string)
+	key := d.Get(sslKeyKey).(string)
+	switch {
+	case cert != "" && key == "":
+		diags = append(diags, attributeError(cty.GetAttrPath(sslKeyKey), "Missing private key", "sslkey must be set when sslcert is set"))
+	case cert == "" && key != "":
+		diags = append(diags, attributeError(cty.GetAttrPath(sslCertKey), "Missing certificate", "sslcert must be set when sslkey is set"))
+	case cert != "" && key != "":
+		if _, err := tls.X509KeyPair([]byte(cert), []byte(key)); err != nil {
+			diags = append(diags, attributeError(cty.GetAttrPath(sslKeyKey), "Private key does not match certificate", err.Error()))
+		}
+	}
+
+	if d.Get(skipVerifyKey).(bool) && d.Get(sslRootCertKey).(string) != "" {
+		diags = append(diags, attributeWarning(cty.GetAttrPath(skipVerifyKey), "Server certificate will not be verified",
+			"sslrootcert is set, but it is not used to verify the server certificate because skip_verify is true"))
+	}
+
+	return diags
+}
+
+// serverIdentityWarning warns that the admin credentials are sent to an unverified server. The
+// combination of skip_verify with sslrootcert is already reported by validateProviderConfig.
+func serverIdentityWarning(factory connectionFactory) diag.Diagnostics {
+	if factory.verifiesServerIdentity() || factory.hasCACertificate() {
+		return nil
+	}
+	return diag.Diagnostics{attributeWarning(cty.GetAttrPath(skipVerifyKey), "Server identity is not verified",
+		"the admin credentials will be sent to the server without verifying its certificate chain and host name")}
+}
+
+func attributeError(path cty.Path, summary, detail string) diag.Diagnostic {
+	return diag.Diagnostic{Severity: diag.Error, Summary: summary, Detail: detail, AttributePath: path}
+}
+
+func attributeWarning(path cty.Path, summary, detail string) diag.Diagnostic {
+	return diag.Diagnostic{Severity: diag.Warning, Summary: summary, Detail: detail, AttributePath: path}
+}
@@ -1,8 +1,13 @@
 package csbmysql
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"regexp"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func quotedIdentifier(identifier string) string {
@@ -13,6 +18,97 @@ func quotedString(originalString string) string {
 	return escapeStringEnclosingCharacter(originalString, "'")
 }
 
+// quotedAccount quotes an account name given as user@host, or just user for any host
+func quotedAccount(account string) string {
+	i := strings.LastIndex(account, "@")
+	if i < 0 {
+		return fmt.Sprintf("%s@%s", quotedString(account), quotedString("%"))
+	}
+	return fmt.Sprintf("%s@%s", quotedString(account[:i]), quotedString(account[i+1:]))
+}
+
+// suppressEquivalentAccount ignores the difference between user and user@%, as a configured
+// account is read back from the server with its host
+func suppressEquivalentAccount(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return newValue != "" && quotedAccount(oldValue) == quotedAccount(newValue)
+}
+
 func escapeStringEnclosingCharacter(originalString string, character string) string {
 	return fmt.Sprintf("%[1]s%[2]s%[1]s", character, strings.NewReplacer(character, character+character).Replace(originalString))
 }
+
+// queryNameValues runs a query returning two columns, such as SHOW VARIABLES or SHOW STATUS,
+// and returns the result as a map from the first column to the second.
+func queryNameValues(ctx context.Context, db *sql.DB, query string, args ...any) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var name, value sql.NullString
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		result[name.String] = value.String
+	}
+
+	return result, rows.Err()
+}
+
+// queryStrings runs a query returning a single column and returns all its values.
+func queryStrings(ctx context.Context, db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	result := make([]string, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+
+	return result, rows.Err()
+}
+
+// isEnabled interprets the value of a boolean system variable
+func isEnabled(value string) bool {
+	switch strings.ToUpper(value) {
+	case "ON", "1", "TRUE", "YES":
+		return true
+	default:
+		return false
+	}
+}
+
+// filterByRegex keeps the items whose value for key matches the regular expression, all of them if it is empty.
+// The expression is compiled here even though it is checked with validation.StringIsValidRegExp, which is
+// skipped when the value is unknown at validation time.
+func filterByRegex(items []map[string]any, key, expression string) ([]map[string]any, error) {
+	if expression == "" {
+		return items, nil
+	}
+
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", expression, err)
+	}
+	filtered := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if re.MatchString(item[key].(string)) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,27 @@
+package csbmysql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("filterByRegex", func() {
+	items := []map[string]any{{"name": "app_db"}, {"name": "other"}}
+
+	It("keeps the items matching the expression", func() {
+		filtered, err := filterByRegex(items, "name", "^app_")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filtered).To(Equal([]map[string]any{{"name": "app_db"}}))
+	})
+
+	It("keeps all the items when the expression is empty", func() {
+		filtered, err := filterByRegex(items, "name", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filtered).To(Equal(items))
+	})
+
+	It("fails on an invalid expression rather than panicking", func() {
+		_, err := filterByRegex(items, "name", "app_(")
+		Expect(err).To(MatchError(ContainSubstring(`invalid regular expression "app_("`)))
+	})
+})
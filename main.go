@@ -1,13 +1,23 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 
 	"github.com/cloudfoundry/terraform-provider-csbmysql/csbmysql"
 )
 
+const providerAddress = "cloudfoundry.org/cloud-service-broker/csbmysql"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: csbmysql.Provider,
-	})
+	server, err := csbmysql.NewProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tf5server.Serve(providerAddress, server); err != nil {
+		log.Fatal(err)
+	}
 }